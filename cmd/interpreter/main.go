@@ -1,16 +1,30 @@
 package main
 
 import (
-	"github.com/davecgh/go-spew/spew"
+	"flag"
+	"fmt"
+	"github.com/njirem95/simple-pascal/pkg/diagnostic"
 	"github.com/njirem95/simple-pascal/pkg/parser"
 	"github.com/njirem95/simple-pascal/pkg/scanner"
+	"github.com/njirem95/simple-pascal/pkg/semantic"
+	"github.com/njirem95/simple-pascal/pkg/visitor"
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
+	"strings"
 )
 
 func main() {
-	file, err := ioutil.ReadFile(os.Args[1])
+	dump := flag.Bool("dump", false, "print the global memory once the program has finished")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s [-dump] file\n", os.Args[0])
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	file, err := ioutil.ReadFile(path)
 	if err != nil {
 		log.Fatal("unable to read file", err)
 	}
@@ -19,13 +33,94 @@ func main() {
 	if err != nil {
 		log.Fatal("lexer error:", err)
 	}
+	lexer.File = path
 
 	parser := parser.New(lexer)
-	statements, err := parser.Program()
+	program, err := parser.Program()
+	if err != nil {
+		report(string(file), err)
+	}
+
+	analyzer := semantic.New()
+	scope, err := analyzer.Analyze(program)
+	if err != nil {
+		report(string(file), err)
+	}
+
+	checker := semantic.NewChecker(scope)
+	err = checker.Check(program)
 	if err != nil {
-		log.Fatal("parse error:", err)
+		report(string(file), err)
+	}
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	if err != nil {
+		report(string(file), err)
+	}
+
+	if *dump {
+		printMemory(interpreter)
+	}
+}
+
+// printMemory prints the final state of the global memory, sorted by variable name. Declared
+// variables are printed together with their type, even when they were never assigned.
+func printMemory(interpreter *visitor.Visitor) {
+	names := make(map[string]bool)
+	for name := range interpreter.GlobalTypes {
+		names[name] = true
+	}
+	for name := range interpreter.GlobalMemory {
+		names[name] = true
+	}
+
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		value := "undefined"
+		if assigned, ok := interpreter.GlobalMemory[name]; ok {
+			value = visitor.Format(assigned)
+		}
+
+		if typeName, ok := interpreter.GlobalTypes[name]; ok {
+			fmt.Printf("%s: %s = %s\n", name, strings.ToUpper(typeName), value)
+		} else {
+			fmt.Printf("%s = %s\n", name, value)
+		}
+	}
+}
+
+// report prints the error to stderr and exits. Errors that refer to a span of the
+// source code are rendered together with the offending line.
+func report(source string, err error) {
+	if errorList, ok := err.(parser.ErrorList); ok {
+		for _, parseError := range errorList {
+			fmt.Fprint(os.Stderr, diagnostic.Render(source, parseError.Span, parseError.Message()))
+		}
+		os.Exit(1)
+	}
+
+	if parseError, ok := err.(*parser.ParseError); ok {
+		fmt.Fprint(os.Stderr, diagnostic.Render(source, parseError.Span, parseError.Message()))
+		os.Exit(1)
+	}
+
+	if errorList, ok := err.(semantic.ErrorList); ok {
+		for _, semanticError := range errorList {
+			fmt.Fprint(os.Stderr, diagnostic.Render(source, semanticError.Span, semanticError.Message))
+		}
+		os.Exit(1)
+	}
+
+	if runtimeError, ok := err.(*visitor.RuntimeError); ok {
+		fmt.Fprint(os.Stderr, diagnostic.Render(source, runtimeError.Span, "runtime error: "+runtimeError.Message))
+		os.Exit(1)
 	}
 
-	// TODO interpret statements
-	spew.Dump(statements)
+	log.Fatal(err)
 }
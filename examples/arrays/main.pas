@@ -0,0 +1,69 @@
+{ Sorts a list of numbers, counts the letters of a word and multiplies two matrices }
+PROGRAM Arrays;
+VAR
+    numbers        : ARRAY[1..8] OF INTEGER;
+    counts         : ARRAY['a'..'z'] OF INTEGER;
+    a, b, product  : ARRAY[1..2, 1..2] OF INTEGER;
+    word           : STRING;
+    c              : CHAR;
+    i, j, k        : INTEGER;
+    sorted         : BOOLEAN;
+
+PROCEDURE Swap(VAR x, y : INTEGER);
+VAR
+    t : INTEGER;
+BEGIN
+    t := x;
+    x := y;
+    y := t
+END;
+
+BEGIN
+    numbers[1] := 5; numbers[2] := 3; numbers[3] := 8; numbers[4] := 1;
+    numbers[5] := 9; numbers[6] := 2; numbers[7] := 7; numbers[8] := 4;
+
+    { Bubble sort swaps neighbours until no pair is out of order }
+    REPEAT
+        sorted := TRUE;
+        FOR i := 1 TO 7 DO
+            IF numbers[i] > numbers[i + 1] THEN
+            BEGIN
+                Swap(numbers[i], numbers[i + 1]);
+                sorted := FALSE
+            END
+    UNTIL sorted;
+
+    FOR i := 1 TO 8 DO
+        Write(numbers[i]:3);
+    WriteLn;
+
+    { The letters index the counts directly }
+    FOR i := Ord('a') TO Ord('z') DO
+        counts[Chr(i)] := 0;
+    word := 'mississippi';
+    FOR i := 1 TO 11 DO
+    BEGIN
+        CASE i OF
+            1: c := 'm';
+            2, 5, 8, 11: c := 'i';
+            3, 4, 6, 7: c := 's';
+            9, 10: c := 'p'
+        END;
+        Inc(counts[c])
+    END;
+    WriteLn(word, ': i=', counts['i'], ' m=', counts['m'], ' p=', counts['p'], ' s=', counts['s']);
+
+    { a[i, j] is the same element as a[i][j] }
+    a[1, 1] := 1; a[1, 2] := 2; a[2][1] := 3; a[2][2] := 4;
+    b := a;
+    b[1, 1] := 0;
+    FOR i := 1 TO 2 DO
+        FOR j := 1 TO 2 DO
+        BEGIN
+            product[i, j] := 0;
+            FOR k := 1 TO 2 DO
+                product[i, j] := product[i, j] + a[i, k] * b[k, j]
+        END;
+    WriteLn(product[1, 1]:4, product[1, 2]:4);
+    WriteLn(product[2, 1]:4, product[2, 2]:4)
+END.
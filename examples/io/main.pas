@@ -0,0 +1,32 @@
+{ Reads a name and a few numbers from the standard input and writes a table of their squares
+  and square roots, for instance: printf 'Ada\n3 16 2 9\n' | interpreter main.pas }
+PROGRAM InputOutput;
+VAR
+    name            : STRING;
+    count, i, value : INTEGER;
+    root            : REAL;
+
+BEGIN
+    { ReadLn reads the remainder of the line into a STRING and skips the line end }
+    Write('Name: ');
+    ReadLn(name);
+    WriteLn('Hello, ', name, '!');
+
+    { Read skips the whitespace in front of a number, including line ends }
+    Read(count);
+    WriteLn('value':6, 'square':8, 'root':8);
+    FOR i := 1 TO count DO
+    BEGIN
+        Read(value);
+
+        { Newton's method approximates the square root }
+        root := value;
+        IF value > 0 THEN
+            REPEAT
+                root := (root + value / root) / 2
+            UNTIL root * root - value < 0.000001;
+
+        { A field width right-aligns the value, a REAL is written with the number of decimals }
+        WriteLn(value:6, value * value:8, root:8:3)
+    END
+END.
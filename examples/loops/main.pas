@@ -0,0 +1,44 @@
+{ Computes a few values with the three kinds of loops, the interpreter prints their final values
+  when it is run with -dump. }
+PROGRAM Loops;
+VAR
+    i, n, factorial, sum : INTEGER;
+    a, b, gcd, digits    : INTEGER;
+    root                 : REAL;
+
+BEGIN
+    { FOR counts from the first to the last value, the control variable is undefined afterwards }
+    n := 10;
+    factorial := 1;
+    FOR i := 1 TO n DO
+        factorial := factorial * i;
+
+    sum := 0;
+    FOR i := n DOWNTO 1 DO
+        IF i MOD 2 = 0 THEN
+            sum := sum + i;
+
+    (* WHILE checks its condition before every iteration: Euclid's algorithm *)
+    a := 1071;
+    b := 462;
+    WHILE b <> 0 DO
+    BEGIN
+        gcd := b;
+        b := a MOD b;
+        a := gcd
+    END;
+
+    // REPEAT runs its statements at least once
+    digits := 0;
+    n := factorial;
+    REPEAT
+        digits := digits + 1;
+        n := n DIV 10
+    UNTIL n = 0;
+
+    { Newton's method for the square root of two }
+    root := 1;
+    REPEAT
+        root := (root + 2 / root) / 2
+    UNTIL (root * root - 2 < 1E-12) AND (root * root - 2 > -1E-12)
+END.
@@ -0,0 +1,64 @@
+{ Declares recursive functions and nested procedures, the interpreter prints the final values
+  of the global variables when it is run with -dump. }
+PROGRAM Procedures;
+VAR
+    n, factorial, fibonacci, total : INTEGER;
+    low, high                      : INTEGER;
+    average                        : REAL;
+
+FUNCTION Fact(n : INTEGER) : INTEGER;
+BEGIN
+    IF n <= 1 THEN
+        Fact := 1
+    ELSE
+        Fact := n * Fact(n - 1)
+END;
+
+FUNCTION Fib(n : INTEGER) : INTEGER;
+BEGIN
+    IF n < 2 THEN
+        Fib := n
+    ELSE
+        Fib := Fib(n - 1) + Fib(n - 2)
+END;
+
+{ VAR parameters refer to the variables passed as argument }
+PROCEDURE Swap(VAR a, b : INTEGER);
+VAR
+    temporary : INTEGER;
+BEGIN
+    temporary := a;
+    a := b;
+    b := temporary
+END;
+
+{ CONST parameters can't be assigned }
+PROCEDURE Statistics(CONST first, last : INTEGER);
+VAR
+    i, count : INTEGER;
+
+    { A nested procedure can access the variables of the procedures it is declared in }
+    PROCEDURE Add(value : INTEGER);
+    BEGIN
+        total := total + value;
+        count := count + 1
+    END;
+
+BEGIN
+    total := 0;
+    count := 0;
+    FOR i := first TO last DO
+        Add(i);
+    average := total / count
+END;
+
+BEGIN
+    n := 10;
+    factorial := Fact(n);
+    fibonacci := Fib(n);
+    low := n;
+    high := 1;
+    IF low > high THEN
+        Swap(low, high);
+    Statistics(low, high)
+END.
@@ -0,0 +1,61 @@
+{ Moves points around, keeps a small address book and copies records }
+PROGRAM Records;
+TYPE
+    Point  = RECORD
+        x, y : INTEGER
+    END;
+    Person = RECORD
+        name : STRING;
+        age  : INTEGER;
+        home : Point
+    END;
+    Book   = ARRAY[1..3] OF Person;
+
+VAR
+    p, q   : Point;
+    people : Book;
+    oldest : Person;
+    i      : INTEGER;
+
+PROCEDURE Move(VAR target : Point; dx, dy : INTEGER);
+BEGIN
+    target.x := target.x + dx;
+    target.y := target.y + dy
+END;
+
+BEGIN
+    p.x := 1;
+    p.y := 2;
+    Move(p, 3, 4);
+    WriteLn('p = (', p.x, ', ', p.y, ')');
+
+    { Assigning a record copies all of its fields }
+    q := p;
+    q.x := 0;
+    WriteLn('p.x = ', p.x, ', q.x = ', q.x);
+
+    WITH people[1] DO
+    BEGIN
+        name := 'Ada';
+        age := 36;
+        home := p
+    END;
+    WITH people[2], home DO
+    BEGIN
+        name := 'Alan';
+        age := 41;
+        x := 7;
+        y := 8
+    END;
+    people[3].name := 'Grace';
+    people[3].age := 85;
+    people[3].home := q;
+    Move(people[3].home, 1, 1);
+
+    oldest := people[1];
+    FOR i := 2 TO 3 DO
+        IF people[i].age > oldest.age THEN
+            oldest := people[i];
+    WITH oldest DO
+        WriteLn(name, ' is ', age, ' and lives at (', home.x, ', ', home.y, ')')
+END.
@@ -1,7 +1,18 @@
+{ Assigns a few global variables, the interpreter prints their final values when it is run
+  with -dump. }
+PROGRAM Variables;
+VAR
+    number : INTEGER;
+    x, z   : INTEGER;
+    y, pi  : REAL;
+
 BEGIN
     number := 123;
     BEGIN
         x := 12;
-        y := x / 2
-    END
-END.
\ No newline at end of file
+        y := x / 2; (* the division operator always results in a REAL *)
+        z := number DIV x + number MOD x
+    END;
+    pi := 3.14159;
+    y := y * pi + 1.5E-3
+END.
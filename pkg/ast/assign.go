@@ -3,7 +3,12 @@ package ast
 import "github.com/njirem95/simple-pascal/pkg/scanner/token"
 
 type Assign struct {
-	Left     interface{}
+	Left     Expr
 	Operator token.Token
 	Right    Expr
+	Span     token.Span
+}
+
+func (a *Assign) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitAssign(a)
 }
@@ -5,7 +5,12 @@ import (
 )
 
 type BinOp struct {
-	Left     interface{}
+	Left     Expr
 	Operator token.Token
-	Right    interface{}
+	Right    Expr
+	Span     token.Span
+}
+
+func (b *BinOp) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitBinOp(b)
 }
@@ -0,0 +1,15 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Block contains the declarations of a program, procedure or function followed by its
+// compound statement.
+type Block struct {
+	Declarations []Declaration
+	Compound     *Compound
+	Span         token.Span
+}
+
+func (b *Block) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitBlock(b)
+}
@@ -0,0 +1,14 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Boolean is one of the literals TRUE and FALSE.
+type Boolean struct {
+	Token token.Token
+	Value bool
+	Span  token.Span
+}
+
+func (b *Boolean) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitBoolean(b)
+}
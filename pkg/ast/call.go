@@ -0,0 +1,42 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// ProcedureCall is the statement that calls a procedure with the arguments.
+type ProcedureCall struct {
+	Name      string
+	Token     token.Token
+	Arguments []Expr
+	Span      token.Span
+}
+
+func (p *ProcedureCall) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitProcedureCall(p)
+}
+
+// FunctionCall is the expression that calls a function with the arguments, its value is the
+// result of the function.
+type FunctionCall struct {
+	Name      string
+	Token     token.Token
+	Arguments []Expr
+	Span      token.Span
+}
+
+func (f *FunctionCall) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitFunctionCall(f)
+}
+
+// WriteParam is an argument of Write or WriteLn with the minimum width of the field the value
+// is written in, for instance x:8. Precision is the number of decimals of a REAL value written
+// in fixed-point notation, for instance r:10:2, it's nil when there is none.
+type WriteParam struct {
+	Expression Expr
+	Width      Expr
+	Precision  Expr
+	Span       token.Span
+}
+
+func (w *WriteParam) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitWriteParam(w)
+}
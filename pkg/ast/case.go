@@ -0,0 +1,39 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Case executes the statement of the branch with a label that matches the value of the
+// expression. Else is nil when the statement has no ELSE part.
+type Case struct {
+	Expression Expr
+	Branches   []*CaseBranch
+	Else       []Statement
+	Span       token.Span
+}
+
+func (c *Case) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCase(c)
+}
+
+// CaseBranch is a statement together with the labels that select it.
+type CaseBranch struct {
+	Labels    []*CaseLabel
+	Statement Statement
+	Span      token.Span
+}
+
+func (c *CaseBranch) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCaseBranch(c)
+}
+
+// CaseLabel is either a single constant or the range of constants from Low to High. High is
+// nil when the label is a single constant.
+type CaseLabel struct {
+	Low  Expr
+	High Expr
+	Span token.Span
+}
+
+func (c *CaseLabel) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCaseLabel(c)
+}
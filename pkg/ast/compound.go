@@ -0,0 +1,13 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Compound is a list of statements enclosed by BEGIN and END.
+type Compound struct {
+	Statements []Statement
+	Span       token.Span
+}
+
+func (c *Compound) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCompound(c)
+}
@@ -0,0 +1,5 @@
+package ast
+
+type Declaration interface {
+	Node
+}
@@ -1,4 +1,11 @@
 package ast
 
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
 type Empty struct {
+	Span token.Span
+}
+
+func (e *Empty) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitEmpty(e)
 }
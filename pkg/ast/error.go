@@ -0,0 +1,13 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Error takes the place of a statement that contains syntax errors, it spans the tokens
+// that were skipped by the parser.
+type Error struct {
+	Span token.Span
+}
+
+func (e *Error) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitError(e)
+}
@@ -1,8 +1,84 @@
 package ast
 
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
 type Expr interface {
 	Node
 }
 
+// Node is a node of the abstract syntax tree.
 type Node interface {
+	// Accept calls the method of the visitor for the type of the node and returns its result.
+	Accept(visitor Visitor) (interface{}, error)
+}
+
+// SpanOf returns the span of the source code the node was parsed from.
+func SpanOf(node Node) token.Span {
+	switch n := node.(type) {
+	case *Num:
+		return n.Span
+	case *Boolean:
+		return n.Span
+	case *Char:
+		return n.Span
+	case *String:
+		return n.Span
+	case *BinOp:
+		return n.Span
+	case *UnaryOp:
+		return n.Span
+	case *Variable:
+		return n.Span
+	case *Field:
+		return n.Span
+	case *Assign:
+		return n.Span
+	case *Compound:
+		return n.Span
+	case *If:
+		return n.Span
+	case *While:
+		return n.Span
+	case *Repeat:
+		return n.Span
+	case *For:
+		return n.Span
+	case *With:
+		return n.Span
+	case *Case:
+		return n.Span
+	case *CaseBranch:
+		return n.Span
+	case *CaseLabel:
+		return n.Span
+	case *Empty:
+		return n.Span
+	case *Error:
+		return n.Span
+	case *Program:
+		return n.Span
+	case *Block:
+		return n.Span
+	case *VarDecl:
+		return n.Span
+	case *TypeDecl:
+		return n.Span
+	case *TypeSpec:
+		return n.Span
+	case *Subrange:
+		return n.Span
+	case *ProcedureDecl:
+		return n.Span
+	case *FunctionDecl:
+		return n.Span
+	case *Param:
+		return n.Span
+	case *ProcedureCall:
+		return n.Span
+	case *FunctionCall:
+		return n.Span
+	case *WriteParam:
+		return n.Span
+	}
+	return token.Span{}
 }
@@ -0,0 +1,18 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// For executes the body once for every value of the control variable from Start to End. The
+// direction is either the TO or the DOWNTO token.
+type For struct {
+	Variable  *Variable
+	Start     Expr
+	Direction token.Token
+	End       Expr
+	Body      Statement
+	Span      token.Span
+}
+
+func (f *For) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitFor(f)
+}
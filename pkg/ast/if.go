@@ -0,0 +1,16 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// If executes the Then statement when the condition holds and the Else statement otherwise.
+// Else is nil when the statement has no ELSE part.
+type If struct {
+	Condition Expr
+	Then      Statement
+	Else      Statement
+	Span      token.Span
+}
+
+func (i *If) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitIf(i)
+}
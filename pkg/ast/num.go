@@ -5,4 +5,9 @@ import "github.com/njirem95/simple-pascal/pkg/scanner/token"
 type Num struct {
 	Token  token.Token
 	Lexeme string
+	Span   token.Span
+}
+
+func (n *Num) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitNum(n)
 }
@@ -0,0 +1,46 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// ProcedureDecl declares a procedure together with its parameters and its block.
+type ProcedureDecl struct {
+	Name       string
+	Token      token.Token
+	Parameters []*Param
+	Block      *Block
+	Span       token.Span
+}
+
+func (p *ProcedureDecl) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitProcedureDecl(p)
+}
+
+// FunctionDecl declares a function, the result of the function is assigned to its name
+// inside its block.
+type FunctionDecl struct {
+	Name       string
+	Token      token.Token
+	Parameters []*Param
+	ReturnType *TypeSpec
+	Block      *Block
+	Span       token.Span
+}
+
+func (f *FunctionDecl) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitFunctionDecl(f)
+}
+
+// Param is a formal parameter of a procedure or function, the parameter list "a, b : INTEGER"
+// results in a Param for both a and b. A VAR parameter refers to the variable that is passed
+// as argument, a CONST parameter can't be assigned.
+type Param struct {
+	Variable  *Variable
+	Type      *TypeSpec
+	Reference bool
+	Constant  bool
+	Span      token.Span
+}
+
+func (p *Param) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitParam(p)
+}
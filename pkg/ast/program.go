@@ -0,0 +1,15 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Program is the root of the abstract syntax tree. The name is empty when the program
+// doesn't start with a program header.
+type Program struct {
+	Name  string
+	Block *Block
+	Span  token.Span
+}
+
+func (p *Program) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitProgram(p)
+}
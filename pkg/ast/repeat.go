@@ -0,0 +1,15 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Repeat executes the statements until the condition holds, the condition is evaluated after
+// every iteration.
+type Repeat struct {
+	Statements []Statement
+	Condition  Expr
+	Span       token.Span
+}
+
+func (r *Repeat) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitRepeat(r)
+}
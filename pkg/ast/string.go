@@ -0,0 +1,26 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// Char is a character string of a single character, for instance 'a' or #65.
+type Char struct {
+	Token token.Token
+	Value byte
+	Span  token.Span
+}
+
+func (c *Char) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitChar(c)
+}
+
+// String is a character string that doesn't consist of a single character, for instance
+// 'abc' or the empty string. Value contains the characters without the quotes.
+type String struct {
+	Token token.Token
+	Value string
+	Span  token.Span
+}
+
+func (s *String) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitString(s)
+}
@@ -0,0 +1,16 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// TypeDecl declares a name for a type, variables can be declared with the name instead of the
+// type it stands for.
+type TypeDecl struct {
+	Name  string
+	Token token.Token
+	Type  *TypeSpec
+	Span  token.Span
+}
+
+func (t *TypeDecl) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitTypeDecl(t)
+}
@@ -0,0 +1,32 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// TypeSpec refers to a type by its name, for instance INTEGER or a type declared in a TYPE
+// section, or describes an ARRAY or a RECORD type. An ARRAY type has the name array, Ranges
+// contains the range of the indexes of every dimension and Element is the type of the elements.
+// A RECORD type has the name record and Fields contains the declarations of its fields.
+type TypeSpec struct {
+	Token   token.Token
+	Name    string
+	Ranges  []*Subrange
+	Element *TypeSpec
+	Fields  []*VarDecl
+	Span    token.Span
+}
+
+func (t *TypeSpec) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitTypeSpec(t)
+}
+
+// Subrange is the range of constants from Low to High, for instance the range 1..10 of the
+// indexes of an ARRAY type.
+type Subrange struct {
+	Low  Expr
+	High Expr
+	Span token.Span
+}
+
+func (s *Subrange) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitSubrange(s)
+}
@@ -4,5 +4,10 @@ import "github.com/njirem95/simple-pascal/pkg/scanner/token"
 
 type UnaryOp struct {
 	Operator   token.Token
-	Expression interface{}
+	Expression Expr
+	Span       token.Span
+}
+
+func (u *UnaryOp) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitUnaryOp(u)
 }
@@ -0,0 +1,15 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// VarDecl declares a single variable, the declaration "x, y : INTEGER" results in a VarDecl
+// for both x and y.
+type VarDecl struct {
+	Variable *Variable
+	Type     *TypeSpec
+	Span     token.Span
+}
+
+func (v *VarDecl) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitVarDecl(v)
+}
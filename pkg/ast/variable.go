@@ -2,7 +2,29 @@ package ast
 
 import "github.com/njirem95/simple-pascal/pkg/scanner/token"
 
+// Variable refers to a variable by its name. Selectors select a part of the variable in order,
+// an index selects an element of an ARRAY and a Field selects a field of a RECORD. Both a[i, j]
+// and a[i][j] have the indexes i and j as selectors, p.x[1] has the field x followed by the
+// index 1.
 type Variable struct {
+	Name      string
+	Token     token.Token
+	Selectors []Expr
+	Span      token.Span
+}
+
+func (v *Variable) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitVariable(v)
+}
+
+// Field selects the field with the name from a RECORD, it only occurs as a selector of a
+// variable.
+type Field struct {
 	Name  string
 	Token token.Token
+	Span  token.Span
+}
+
+func (f *Field) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitField(f)
 }
@@ -0,0 +1,42 @@
+package ast
+
+// Visitor has a method for every type of node. Every node calls the method for its type from
+// Accept, so a pass over the abstract syntax tree, like the interpreter or the type checker,
+// doesn't need a type switch over the nodes. The result of a visit depends on the pass, the
+// interpreter for instance returns the value of an expression.
+type Visitor interface {
+	VisitProgram(node *Program) (interface{}, error)
+	VisitBlock(node *Block) (interface{}, error)
+	VisitVarDecl(node *VarDecl) (interface{}, error)
+	VisitTypeDecl(node *TypeDecl) (interface{}, error)
+	VisitTypeSpec(node *TypeSpec) (interface{}, error)
+	VisitSubrange(node *Subrange) (interface{}, error)
+	VisitProcedureDecl(node *ProcedureDecl) (interface{}, error)
+	VisitFunctionDecl(node *FunctionDecl) (interface{}, error)
+	VisitParam(node *Param) (interface{}, error)
+
+	VisitCompound(node *Compound) (interface{}, error)
+	VisitAssign(node *Assign) (interface{}, error)
+	VisitProcedureCall(node *ProcedureCall) (interface{}, error)
+	VisitIf(node *If) (interface{}, error)
+	VisitWhile(node *While) (interface{}, error)
+	VisitRepeat(node *Repeat) (interface{}, error)
+	VisitFor(node *For) (interface{}, error)
+	VisitWith(node *With) (interface{}, error)
+	VisitCase(node *Case) (interface{}, error)
+	VisitCaseBranch(node *CaseBranch) (interface{}, error)
+	VisitCaseLabel(node *CaseLabel) (interface{}, error)
+	VisitEmpty(node *Empty) (interface{}, error)
+	VisitError(node *Error) (interface{}, error)
+
+	VisitNum(node *Num) (interface{}, error)
+	VisitBoolean(node *Boolean) (interface{}, error)
+	VisitChar(node *Char) (interface{}, error)
+	VisitString(node *String) (interface{}, error)
+	VisitVariable(node *Variable) (interface{}, error)
+	VisitField(node *Field) (interface{}, error)
+	VisitFunctionCall(node *FunctionCall) (interface{}, error)
+	VisitWriteParam(node *WriteParam) (interface{}, error)
+	VisitUnaryOp(node *UnaryOp) (interface{}, error)
+	VisitBinOp(node *BinOp) (interface{}, error)
+}
@@ -0,0 +1,237 @@
+package ast
+
+import (
+	"reflect"
+)
+
+// Walker is a Visitor that visits the children of every node in the order they appear in the
+// source code, it returns no results and stops at the first error. A pass that is only
+// interested in some types of nodes embeds the Walker and overrides the methods for those types.
+//
+// Visitor is the visitor the children are visited with. It has to be set to the pass that
+// embeds the Walker, otherwise the methods of the pass aren't called for the children. The
+// Walker visits the children with itself when Visitor is nil.
+type Walker struct {
+	Visitor Visitor
+}
+
+// Walk visits the node, a missing node is skipped.
+func (w *Walker) Walk(node Node) error {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return nil
+	}
+
+	var visitor Visitor = w
+	if w.Visitor != nil {
+		visitor = w.Visitor
+	}
+	_, err := node.Accept(visitor)
+	return err
+}
+
+// walkAll visits the nodes in order.
+func (w *Walker) walkAll(nodes ...Node) (interface{}, error) {
+	for _, node := range nodes {
+		if err := w.Walk(node); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// walkStatements visits the statements in order.
+func (w *Walker) walkStatements(statements []Statement) (interface{}, error) {
+	for _, statement := range statements {
+		if err := w.Walk(statement); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// walkExpressions visits the expressions in order.
+func (w *Walker) walkExpressions(expressions []Expr) (interface{}, error) {
+	for _, expression := range expressions {
+		if err := w.Walk(expression); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (w *Walker) VisitProgram(node *Program) (interface{}, error) {
+	return w.walkAll(node.Block)
+}
+
+func (w *Walker) VisitBlock(node *Block) (interface{}, error) {
+	for _, declaration := range node.Declarations {
+		if err := w.Walk(declaration); err != nil {
+			return nil, err
+		}
+	}
+	return w.walkAll(node.Compound)
+}
+
+func (w *Walker) VisitVarDecl(node *VarDecl) (interface{}, error) {
+	return w.walkAll(node.Variable, node.Type)
+}
+
+func (w *Walker) VisitTypeDecl(node *TypeDecl) (interface{}, error) {
+	return w.walkAll(node.Type)
+}
+
+func (w *Walker) VisitTypeSpec(node *TypeSpec) (interface{}, error) {
+	for _, subrange := range node.Ranges {
+		if err := w.Walk(subrange); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Walk(node.Element); err != nil {
+		return nil, err
+	}
+	for _, field := range node.Fields {
+		if err := w.Walk(field); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (w *Walker) VisitSubrange(node *Subrange) (interface{}, error) {
+	return w.walkAll(node.Low, node.High)
+}
+
+func (w *Walker) VisitProcedureDecl(node *ProcedureDecl) (interface{}, error) {
+	for _, parameter := range node.Parameters {
+		if err := w.Walk(parameter); err != nil {
+			return nil, err
+		}
+	}
+	return w.walkAll(node.Block)
+}
+
+func (w *Walker) VisitFunctionDecl(node *FunctionDecl) (interface{}, error) {
+	for _, parameter := range node.Parameters {
+		if err := w.Walk(parameter); err != nil {
+			return nil, err
+		}
+	}
+	return w.walkAll(node.ReturnType, node.Block)
+}
+
+func (w *Walker) VisitParam(node *Param) (interface{}, error) {
+	return w.walkAll(node.Variable, node.Type)
+}
+
+func (w *Walker) VisitCompound(node *Compound) (interface{}, error) {
+	return w.walkStatements(node.Statements)
+}
+
+func (w *Walker) VisitAssign(node *Assign) (interface{}, error) {
+	return w.walkAll(node.Left, node.Right)
+}
+
+func (w *Walker) VisitProcedureCall(node *ProcedureCall) (interface{}, error) {
+	return w.walkExpressions(node.Arguments)
+}
+
+func (w *Walker) VisitIf(node *If) (interface{}, error) {
+	return w.walkAll(node.Condition, node.Then, node.Else)
+}
+
+func (w *Walker) VisitWhile(node *While) (interface{}, error) {
+	return w.walkAll(node.Condition, node.Body)
+}
+
+func (w *Walker) VisitRepeat(node *Repeat) (interface{}, error) {
+	if _, err := w.walkStatements(node.Statements); err != nil {
+		return nil, err
+	}
+	return w.walkAll(node.Condition)
+}
+
+func (w *Walker) VisitFor(node *For) (interface{}, error) {
+	return w.walkAll(node.Variable, node.Start, node.End, node.Body)
+}
+
+func (w *Walker) VisitWith(node *With) (interface{}, error) {
+	for _, variable := range node.Variables {
+		if err := w.Walk(variable); err != nil {
+			return nil, err
+		}
+	}
+	return w.walkAll(node.Body)
+}
+
+func (w *Walker) VisitCase(node *Case) (interface{}, error) {
+	if err := w.Walk(node.Expression); err != nil {
+		return nil, err
+	}
+	for _, branch := range node.Branches {
+		if err := w.Walk(branch); err != nil {
+			return nil, err
+		}
+	}
+	return w.walkStatements(node.Else)
+}
+
+func (w *Walker) VisitCaseBranch(node *CaseBranch) (interface{}, error) {
+	for _, label := range node.Labels {
+		if err := w.Walk(label); err != nil {
+			return nil, err
+		}
+	}
+	return w.walkAll(node.Statement)
+}
+
+func (w *Walker) VisitCaseLabel(node *CaseLabel) (interface{}, error) {
+	return w.walkAll(node.Low, node.High)
+}
+
+func (w *Walker) VisitEmpty(node *Empty) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitError(node *Error) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitNum(node *Num) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitBoolean(node *Boolean) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitChar(node *Char) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitString(node *String) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitVariable(node *Variable) (interface{}, error) {
+	return w.walkExpressions(node.Selectors)
+}
+
+func (w *Walker) VisitField(node *Field) (interface{}, error) {
+	return nil, nil
+}
+
+func (w *Walker) VisitFunctionCall(node *FunctionCall) (interface{}, error) {
+	return w.walkExpressions(node.Arguments)
+}
+
+func (w *Walker) VisitWriteParam(node *WriteParam) (interface{}, error) {
+	return w.walkAll(node.Expression, node.Width, node.Precision)
+}
+
+func (w *Walker) VisitUnaryOp(node *UnaryOp) (interface{}, error) {
+	return w.walkAll(node.Expression)
+}
+
+func (w *Walker) VisitBinOp(node *BinOp) (interface{}, error) {
+	return w.walkAll(node.Left, node.Right)
+}
@@ -0,0 +1,143 @@
+package ast_test
+
+import (
+	"errors"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// variableCollector collects the names of the variables in the order they are visited, the
+// variables in the selectors of a variable are visited after the variable itself.
+type variableCollector struct {
+	ast.Walker
+	names []string
+	stop  string
+}
+
+func (v *variableCollector) VisitVariable(node *ast.Variable) (interface{}, error) {
+	v.names = append(v.names, node.Name)
+	if node.Name == v.stop {
+		return nil, errors.New("stopped at " + node.Name)
+	}
+	return v.Walker.VisitVariable(node)
+}
+
+func variable(name string) *ast.Variable {
+	return &ast.Variable{Name: name}
+}
+
+func TestWalker_Walk(t *testing.T) {
+	// IF a THEN b := c + d ELSE WHILE e DO f(-g); REPEAT UNTIL h
+	input := &ast.Compound{
+		Statements: []ast.Statement{
+			&ast.If{
+				Condition: variable("a"),
+				Then:      &ast.Assign{Left: variable("b"), Right: &ast.BinOp{Left: variable("c"), Right: variable("d")}},
+				Else: &ast.While{
+					Condition: variable("e"),
+					Body: &ast.ProcedureCall{
+						Name:      "f",
+						Arguments: []ast.Expr{&ast.UnaryOp{Expression: variable("g")}},
+					},
+				},
+			},
+			&ast.Repeat{Condition: variable("h")},
+		},
+	}
+
+	collector := &variableCollector{}
+	collector.Visitor = collector
+	err := collector.Walk(input)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "g", "h"}, collector.names)
+
+	collector = &variableCollector{stop: "d"}
+	collector.Visitor = collector
+	err = collector.Walk(input)
+	assert.EqualError(t, err, "stopped at d")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, collector.names)
+}
+
+func TestWalker_Walk_Arrays(t *testing.T) {
+	// VAR x : ARRAY[l..h] OF INTEGER; a[i, j] := b
+	input := &ast.Block{
+		Declarations: []ast.Declaration{
+			&ast.VarDecl{
+				Variable: variable("x"),
+				Type: &ast.TypeSpec{
+					Name:    "array",
+					Ranges:  []*ast.Subrange{{Low: variable("l"), High: variable("h")}},
+					Element: &ast.TypeSpec{Name: "integer"},
+				},
+			},
+		},
+		Compound: &ast.Compound{
+			Statements: []ast.Statement{
+				&ast.Assign{
+					Left:  &ast.Variable{Name: "a", Selectors: []ast.Expr{variable("i"), variable("j")}},
+					Right: variable("b"),
+				},
+			},
+		},
+	}
+
+	collector := &variableCollector{}
+	collector.Visitor = collector
+	err := collector.Walk(input)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x", "l", "h", "a", "i", "j", "b"}, collector.names)
+}
+
+func TestWalker_Walk_Records(t *testing.T) {
+	// TYPE r = RECORD x : ARRAY[l..h] OF INTEGER END; WITH p, q.f[i] DO y := z
+	input := &ast.Block{
+		Declarations: []ast.Declaration{
+			&ast.TypeDecl{
+				Name: "r",
+				Type: &ast.TypeSpec{
+					Name: "record",
+					Fields: []*ast.VarDecl{
+						{
+							Variable: variable("x"),
+							Type: &ast.TypeSpec{
+								Name:    "array",
+								Ranges:  []*ast.Subrange{{Low: variable("l"), High: variable("h")}},
+								Element: &ast.TypeSpec{Name: "integer"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Compound: &ast.Compound{
+			Statements: []ast.Statement{
+				&ast.With{
+					Variables: []*ast.Variable{
+						variable("p"),
+						{Name: "q", Selectors: []ast.Expr{&ast.Field{Name: "f"}, variable("i")}},
+					},
+					Body: &ast.Assign{Left: variable("y"), Right: variable("z")},
+				},
+			},
+		},
+	}
+
+	collector := &variableCollector{}
+	collector.Visitor = collector
+	err := collector.Walk(input)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x", "l", "h", "p", "q", "i", "y", "z"}, collector.names)
+}
+
+func TestWalker_Walk_Missing(t *testing.T) {
+	var block *ast.Block
+	walker := &ast.Walker{}
+	assert.Nil(t, walker.Walk(nil))
+	assert.Nil(t, walker.Walk(block))
+
+	// Without a Visitor the walker visits the children with itself.
+	input := &ast.If{Condition: variable("a"), Then: &ast.Empty{}}
+	_, err := input.Accept(walker)
+	assert.Nil(t, err)
+}
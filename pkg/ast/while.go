@@ -0,0 +1,15 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// While executes the body as long as the condition holds, the condition is evaluated before
+// every iteration.
+type While struct {
+	Condition Expr
+	Body      Statement
+	Span      token.Span
+}
+
+func (w *While) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitWhile(w)
+}
@@ -0,0 +1,16 @@
+package ast
+
+import "github.com/njirem95/simple-pascal/pkg/scanner/token"
+
+// With executes the body with the fields of the RECORD variables accessible by their names. The
+// statement WITH a, b DO s is the same as WITH a DO WITH b DO s, so the fields of b hide the
+// fields of a with the same name.
+type With struct {
+	Variables []*Variable
+	Body      Statement
+	Span      token.Span
+}
+
+func (w *With) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitWith(w)
+}
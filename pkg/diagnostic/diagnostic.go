@@ -0,0 +1,66 @@
+// Package diagnostic is responsible for rendering errors that refer to a span of the source code.
+//
+// For instance, a parse error in the input "begin x := 1 2 end." is rendered as follows:
+//
+//	main.pas:1:14: expected ';' or END, found integer "2"
+//	begin x := 1 2 end.
+//	             ^
+package diagnostic
+
+import (
+	"fmt"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"strings"
+)
+
+// Render formats the message, prefixed with the start position of the span, followed by
+// the line of the source code the span starts on. The span is underlined with carets.
+func Render(source string, span token.Span, message string) string {
+	start := clamp(span.Start.Offset, len(source))
+	end := clamp(span.End.Offset, len(source))
+
+	lineStart := strings.LastIndex(source[:start], "\n") + 1
+	lineEnd := strings.Index(source[start:], "\n")
+	if lineEnd == -1 {
+		lineEnd = len(source)
+	} else {
+		lineEnd += start
+	}
+	line := strings.TrimRight(source[lineStart:lineEnd], "\r")
+
+	// A span that continues on the next lines is underlined up to the end of the first line.
+	if end > lineStart+len(line) {
+		end = lineStart + len(line)
+	}
+
+	// Keep the tabs in front of the caret, so that the caret lines up with the source line.
+	var indent strings.Builder
+	for _, character := range source[lineStart:start] {
+		if character == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+
+	length := end - start
+	if length < 1 {
+		length = 1
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s\n", span.Start, message)
+	fmt.Fprintf(&sb, "%s\n", line)
+	fmt.Fprintf(&sb, "%s%s\n", indent.String(), strings.Repeat("^", length))
+	return sb.String()
+}
+
+func clamp(offset int, max int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > max {
+		return max
+	}
+	return offset
+}
@@ -0,0 +1,50 @@
+package diagnostic_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/diagnostic"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	source := "begin\n\tx := 12;\n\ty := x / 2 z\nend."
+	span := token.Span{
+		Start: token.Position{File: "main.pas", Offset: 28, Line: 3, Column: 13},
+		End:   token.Position{File: "main.pas", Offset: 29, Line: 3, Column: 14},
+	}
+
+	expected := "main.pas:3:13: expected END, found identifier \"z\"\n" +
+		"\ty := x / 2 z\n" +
+		"\t           ^\n"
+
+	assert.Equal(t, expected, diagnostic.Render(source, span, "expected END, found identifier \"z\""))
+}
+
+func TestRender_MultipleCharacters(t *testing.T) {
+	source := "begin x := end."
+	span := token.Span{
+		Start: token.Position{Offset: 11, Line: 1, Column: 12},
+		End:   token.Position{Offset: 14, Line: 1, Column: 15},
+	}
+
+	expected := "1:12: expected integer, found END\n" +
+		"begin x := end.\n" +
+		"           ^^^\n"
+
+	assert.Equal(t, expected, diagnostic.Render(source, span, "expected integer, found END"))
+}
+
+func TestRender_EndOfFile(t *testing.T) {
+	source := "begin\r\nx := 1\r\n"
+	span := token.Span{
+		Start: token.Position{Offset: 15, Line: 3, Column: 1},
+		End:   token.Position{Offset: 15, Line: 3, Column: 1},
+	}
+
+	expected := "3:1: expected END, found end of file\n" +
+		"\n" +
+		"^\n"
+
+	assert.Equal(t, expected, diagnostic.Render(source, span, "expected END, found end of file"))
+}
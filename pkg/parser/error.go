@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"strings"
+)
+
+// ParseError is returned when the parser encounters a token that is not allowed by the grammar.
+type ParseError struct {
+	// Expected contains the token types that would have been accepted.
+	Expected []int
+	Found    token.Token
+	Span     token.Span
+}
+
+func newParseError(found token.Token, expected ...int) *ParseError {
+	return &ParseError{
+		Expected: expected,
+		Found:    found,
+		Span:     found.Span,
+	}
+}
+
+// Message describes the error without its position.
+func (e *ParseError) Message() string {
+	switch e.Found.Type {
+	case token.Illegal:
+		return fmt.Sprintf("illegal character %q", e.Found.Lexeme)
+	case token.UnterminatedComment:
+		return "unterminated comment"
+	case token.UnterminatedString:
+		return "unterminated string"
+	}
+
+	var expected []string
+	for _, tokenType := range e.Expected {
+		expected = append(expected, token.Name(tokenType))
+	}
+
+	var list string
+	switch len(expected) {
+	case 0:
+		list = "nothing"
+	case 1:
+		list = expected[0]
+	default:
+		list = strings.Join(expected[:len(expected)-1], ", ") + " or " + expected[len(expected)-1]
+	}
+
+	found := token.Name(e.Found.Type)
+	switch e.Found.Type {
+	case token.Identifier, token.Int, token.Real, token.String:
+		found = fmt.Sprintf("%s %q", found, e.Found.Lexeme)
+	}
+
+	return fmt.Sprintf("expected %s, found %s", list, found)
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Span.Start, e.Message())
+}
+
+// ErrorList is a list of syntax errors, in the order they were found in the input stream.
+type ErrorList []*ParseError
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
@@ -0,0 +1,69 @@
+package parser_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/njirem95/simple-pascal/pkg/parser"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"github.com/njirem95/simple-pascal/test/mock/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParser_Consume_ParseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+
+	found := token.Token{
+		Type:   token.Identifier,
+		Lexeme: "x",
+		Span: token.Span{
+			Start: token.Position{File: "main.pas", Offset: 6, Line: 2, Column: 1},
+			End:   token.Position{File: "main.pas", Offset: 7, Line: 2, Column: 2},
+		},
+	}
+
+	m.
+		EXPECT().
+		Next().
+		Return(found).
+		AnyTimes()
+
+	p := parser.New(m)
+
+	err := p.Consume(token.Begin)
+	parseError, ok := err.(*parser.ParseError)
+	assert.True(t, ok)
+
+	assert.Equal(t, []int{token.Begin}, parseError.Expected)
+	assert.Equal(t, found, parseError.Found)
+	assert.Equal(t, found.Span, parseError.Span)
+	assert.Equal(t, "main.pas:2:1: expected BEGIN, found identifier \"x\"", parseError.Error())
+}
+
+func TestParser_Factor_ParseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+
+	found := token.Token{
+		Type:   token.EOF,
+		Lexeme: "",
+	}
+
+	m.
+		EXPECT().
+		Next().
+		Return(found).
+		AnyTimes()
+
+	p := parser.New(m)
+
+	_, err := p.Factor()
+	parseError, ok := err.(*parser.ParseError)
+	assert.True(t, ok)
+
+	assert.Equal(t, "expected '+', '-', NOT, integer, real number, TRUE, FALSE, string, '(' or identifier, found end of file", parseError.Message())
+}
@@ -1,46 +1,999 @@
 package parser
 
 import (
-	"errors"
-	"fmt"
 	"github.com/njirem95/simple-pascal/pkg/ast"
 	"github.com/njirem95/simple-pascal/pkg/scanner"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
 )
 
-var (
-	endReachedError = errors.New("reached end of recursion")
-)
+type Parser struct {
+	lexer        scanner.Scanner
+	currentToken token.Token
+
+	// errors contains the syntax errors the parser recovered from.
+	errors ErrorList
+}
+
+func (p *Parser) Consume(tokenType int) error {
+	if tokenType == p.currentToken.Type {
+		p.currentToken = p.lexer.Next()
+		return nil
+	}
+	return newParseError(p.currentToken, tokenType)
+}
+
+// Program parses a complete program, optionally starting with a program header:
+//
+//	program : (PROGRAM variable SEMI)? block DOT
+//
+// The parser recovers from syntax errors in declarations and statements, so the returned
+// program may contain ast.Error nodes. All syntax errors found are returned as an ErrorList.
+func (p *Parser) Program() (*ast.Program, error) {
+	p.errors = nil
+
+	start := p.currentToken
+	node := &ast.Program{}
+
+	if p.currentToken.Type == token.Program {
+		err := p.Consume(token.Program)
+		if err != nil {
+			return nil, p.fail(err)
+		}
+
+		node.Name = p.currentToken.Lexeme
+		err = p.Consume(token.Identifier)
+		if err != nil {
+			return nil, p.fail(err)
+		}
+
+		err = p.Consume(token.Semi)
+		if err != nil {
+			return nil, p.fail(err)
+		}
+	}
+
+	block, err := p.Block()
+	if err != nil {
+		return nil, p.fail(err)
+	}
+	node.Block = block
+
+	end := p.currentToken
+	node.Span = between(start.Span, end.Span)
+	err = p.Consume(token.Dot)
+	if err != nil {
+		node.Span = between(start.Span, block.Span)
+		return node, p.fail(err)
+	}
+
+	if len(p.errors) > 0 {
+		return node, p.errors
+	}
+	return node, nil
+}
+
+// Block parses the declarations followed by the compound statement:
+//
+//	block : declarations compound_statement
+func (p *Parser) Block() (*ast.Block, error) {
+	start := p.currentToken
+
+	declarations, err := p.Declarations()
+	if err != nil {
+		return nil, err
+	}
+
+	compound, err := p.CompoundStmt()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.Block{
+		Declarations: declarations,
+		Compound:     compound,
+		Span:         between(start.Span, compound.Span),
+	}
+	return node, nil
+}
+
+// Declarations parses the type and variable declaration sections and the procedure and function
+// declarations:
+//
+//	declarations : (TYPE (type_declaration SEMI)+ | VAR (variable_declaration SEMI)+ |
+//	                procedure_declaration | function_declaration)*
+//
+// A type or variable declaration that contains syntax errors is skipped up to the next semicolon.
+func (p *Parser) Declarations() ([]ast.Declaration, error) {
+	var declarations []ast.Declaration
+
+	for {
+		switch p.currentToken.Type {
+		case token.Type:
+			nodes, err := p.TypeDeclarations()
+			if err != nil {
+				return nil, err
+			}
+			declarations = append(declarations, nodes...)
+		case token.Var:
+			nodes, err := p.VariableDeclarations()
+			if err != nil {
+				return nil, err
+			}
+			declarations = append(declarations, nodes...)
+		case token.Procedure:
+			node, err := p.ProcedureDeclaration()
+			if err != nil {
+				return nil, err
+			}
+			declarations = append(declarations, node)
+		case token.Function:
+			node, err := p.FunctionDeclaration()
+			if err != nil {
+				return nil, err
+			}
+			declarations = append(declarations, node)
+		default:
+			return declarations, nil
+		}
+	}
+}
+
+// TypeDeclarations parses a single type declaration section:
+//
+//	TYPE (type_declaration SEMI)+
+func (p *Parser) TypeDeclarations() ([]ast.Declaration, error) {
+	var declarations []ast.Declaration
+
+	err := p.Consume(token.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		node, err := p.TypeDeclaration()
+		if err == nil {
+			declarations = append(declarations, node)
+			err = p.Consume(token.Semi)
+		}
+
+		if err != nil {
+			_, err = p.recover(err)
+			if err != nil {
+				return nil, err
+			}
+			if p.currentToken.Type == token.Semi {
+				p.currentToken = p.lexer.Next()
+			}
+		}
+
+		if p.currentToken.Type != token.Identifier {
+			return declarations, nil
+		}
+	}
+}
+
+// TypeDeclaration parses the declaration of a name for a type:
+//
+//	type_declaration : ID EQUAL type_spec
+func (p *Parser) TypeDeclaration() (*ast.TypeDecl, error) {
+	name := p.currentToken
+	err := p.Consume(token.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Equal)
+	if err != nil {
+		return nil, err
+	}
+
+	typeSpec, err := p.TypeSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.TypeDecl{
+		Name:  name.Lexeme,
+		Token: name,
+		Type:  typeSpec,
+		Span:  between(name.Span, typeSpec.Span),
+	}
+	return node, nil
+}
+
+// VariableDeclarations parses a single variable declaration section:
+//
+//	VAR (variable_declaration SEMI)+
+func (p *Parser) VariableDeclarations() ([]ast.Declaration, error) {
+	var declarations []ast.Declaration
+
+	err := p.Consume(token.Var)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		nodes, err := p.VariableDeclaration()
+		if err == nil {
+			declarations = append(declarations, nodes...)
+			err = p.Consume(token.Semi)
+		}
+
+		if err != nil {
+			_, err = p.recover(err)
+			if err != nil {
+				return nil, err
+			}
+			if p.currentToken.Type == token.Semi {
+				p.currentToken = p.lexer.Next()
+			}
+		}
+
+		if p.currentToken.Type != token.Identifier {
+			return declarations, nil
+		}
+	}
+}
+
+// ProcedureDeclaration parses a procedure together with its block:
+//
+//	procedure_declaration : PROCEDURE variable formal_parameter_list? SEMI block SEMI
+func (p *Parser) ProcedureDeclaration() (*ast.ProcedureDecl, error) {
+	start := p.currentToken
+	err := p.Consume(token.Procedure)
+	if err != nil {
+		return nil, err
+	}
+
+	name := p.currentToken
+	err = p.Consume(token.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := p.FormalParameterList()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Semi)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := p.Block()
+	if err != nil {
+		return nil, err
+	}
+
+	end := p.currentToken
+	err = p.Consume(token.Semi)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.ProcedureDecl{
+		Name:       name.Lexeme,
+		Token:      name,
+		Parameters: parameters,
+		Block:      block,
+		Span:       between(start.Span, end.Span),
+	}
+	return node, nil
+}
+
+// FunctionDeclaration parses a function together with the type of its result and its block:
+//
+//	function_declaration : FUNCTION variable formal_parameter_list? COLON type_spec SEMI block SEMI
+func (p *Parser) FunctionDeclaration() (*ast.FunctionDecl, error) {
+	start := p.currentToken
+	err := p.Consume(token.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	name := p.currentToken
+	err = p.Consume(token.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := p.FormalParameterList()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Colon)
+	if err != nil {
+		return nil, err
+	}
+
+	returnType, err := p.TypeSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Semi)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := p.Block()
+	if err != nil {
+		return nil, err
+	}
+
+	end := p.currentToken
+	err = p.Consume(token.Semi)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.FunctionDecl{
+		Name:       name.Lexeme,
+		Token:      name,
+		Parameters: parameters,
+		ReturnType: returnType,
+		Block:      block,
+		Span:       between(start.Span, end.Span),
+	}
+	return node, nil
+}
+
+// FormalParameterList parses the parameters of a procedure or function, nil is returned when
+// the declaration has no parameter list:
+//
+//	formal_parameter_list : LPAREN formal_parameters (SEMI formal_parameters)* RPAREN
+func (p *Parser) FormalParameterList() ([]*ast.Param, error) {
+	if p.currentToken.Type != token.Lparen {
+		return nil, nil
+	}
+
+	err := p.Consume(token.Lparen)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameters []*ast.Param
+	for {
+		nodes, err := p.FormalParameters()
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, nodes...)
+
+		if p.currentToken.Type != token.Semi {
+			break
+		}
+		err = p.Consume(token.Semi)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.Consume(token.Rparen)
+	if err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
+// FormalParameters parses one or more parameters of the same type, optionally passed by
+// reference or as constants:
+//
+//	formal_parameters : (VAR | CONST)? variable (COMMA variable)* COLON type_spec
+func (p *Parser) FormalParameters() ([]*ast.Param, error) {
+	mode := p.currentToken.Type
+	if mode == token.Var || mode == token.Const {
+		err := p.Consume(mode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	declarations, err := p.VariableDeclaration()
+	if err != nil {
+		return nil, err
+	}
+
+	var parameters []*ast.Param
+	for _, declaration := range declarations {
+		declaration := declaration.(*ast.VarDecl)
+		parameters = append(parameters, &ast.Param{
+			Variable:  declaration.Variable,
+			Type:      declaration.Type,
+			Reference: mode == token.Var,
+			Constant:  mode == token.Const,
+			Span:      declaration.Span,
+		})
+	}
+	return parameters, nil
+}
+
+// VariableDeclaration parses the declaration of one or more variables of the same type:
+//
+//	variable_declaration : variable (COMMA variable)* COLON type_spec
+func (p *Parser) VariableDeclaration() ([]ast.Declaration, error) {
+	variable, err := p.Variable()
+	if err != nil {
+		return nil, err
+	}
+	variables := []*ast.Variable{variable}
+
+	for p.currentToken.Type == token.Comma {
+		err = p.Consume(token.Comma)
+		if err != nil {
+			return nil, err
+		}
+
+		variable, err = p.Variable()
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, variable)
+	}
+
+	err = p.Consume(token.Colon)
+	if err != nil {
+		return nil, err
+	}
+
+	typeSpec, err := p.TypeSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	var declarations []ast.Declaration
+	for _, variable := range variables {
+		declarations = append(declarations, &ast.VarDecl{
+			Variable: variable,
+			Type:     typeSpec,
+			Span:     between(variable.Span, typeSpec.Span),
+		})
+	}
+	return declarations, nil
+}
+
+// TypeSpec parses the type of a variable declaration, an identifier refers to one of the standard
+// types INTEGER, REAL, BOOLEAN, CHAR and STRING or to a type declared in a TYPE section:
+//
+//	type_spec : ID | array_type | record_type
+func (p *Parser) TypeSpec() (*ast.TypeSpec, error) {
+	node := &ast.TypeSpec{
+		Token: p.currentToken,
+		Name:  p.currentToken.Lexeme,
+		Span:  p.currentToken.Span,
+	}
+
+	switch p.currentToken.Type {
+	case token.Identifier:
+		err := p.Consume(token.Identifier)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
+	case token.Array:
+		return p.ArrayType()
+	case token.Record:
+		return p.RecordType()
+	}
+
+	return nil, newParseError(p.currentToken, token.Identifier, token.Array, token.Record)
+}
+
+// ArrayType parses an ARRAY type with the range of the indexes of every dimension, the type
+// ARRAY[1..3, 1..3] OF REAL is the same as ARRAY[1..3] OF ARRAY[1..3] OF REAL:
+//
+//	array_type : ARRAY LBRACKET subrange (COMMA subrange)* RBRACKET OF type_spec
+func (p *Parser) ArrayType() (*ast.TypeSpec, error) {
+	node := &ast.TypeSpec{
+		Token: p.currentToken,
+		Name:  p.currentToken.Lexeme,
+	}
+
+	err := p.Consume(token.Array)
+	if err != nil {
+		return nil, err
+	}
+	err = p.Consume(token.Lbracket)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		subrange, err := p.Subrange()
+		if err != nil {
+			return nil, err
+		}
+		node.Ranges = append(node.Ranges, subrange)
+
+		if p.currentToken.Type != token.Comma {
+			break
+		}
+		err = p.Consume(token.Comma)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.Consume(token.Rbracket)
+	if err != nil {
+		return nil, err
+	}
+	err = p.Consume(token.Of)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Element, err = p.TypeSpec()
+	if err != nil {
+		return nil, err
+	}
+	node.Span = between(node.Token.Span, node.Element.Span)
+	return node, nil
+}
+
+// RecordType parses a RECORD type with the declarations of its fields, the semicolon after the
+// last field is optional:
+//
+//	record_type : RECORD variable_declaration (SEMI variable_declaration)* SEMI? END
+func (p *Parser) RecordType() (*ast.TypeSpec, error) {
+	node := &ast.TypeSpec{
+		Token: p.currentToken,
+		Name:  p.currentToken.Lexeme,
+	}
+
+	err := p.Consume(token.Record)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		declarations, err := p.VariableDeclaration()
+		if err != nil {
+			return nil, err
+		}
+		for _, declaration := range declarations {
+			node.Fields = append(node.Fields, declaration.(*ast.VarDecl))
+		}
+
+		if p.currentToken.Type != token.Semi {
+			break
+		}
+		err = p.Consume(token.Semi)
+		if err != nil {
+			return nil, err
+		}
+		if p.currentToken.Type == token.End {
+			break
+		}
+	}
+
+	end := p.currentToken
+	err = p.Consume(token.End)
+	if err != nil {
+		return nil, err
+	}
+	node.Span = between(node.Token.Span, end.Span)
+	return node, nil
+}
+
+// Subrange parses a range of constants, for instance the range of the indexes of an ARRAY type:
+//
+//	subrange : constant RANGE constant
+func (p *Parser) Subrange() (*ast.Subrange, error) {
+	low, err := p.Constant()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	high, err := p.Constant()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.Subrange{
+		Low:  low,
+		High: high,
+		Span: between(ast.SpanOf(low), ast.SpanOf(high)),
+	}
+	return node, nil
+}
+
+func (p *Parser) CompoundStmt() (*ast.Compound, error) {
+	begin := p.currentToken
+	err := p.Consume(token.Begin)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := p.StmtList()
+	if err != nil {
+		return nil, err
+	}
+
+	end := p.currentToken
+	err = p.Consume(token.End)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.Compound{
+		Statements: statements,
+		Span:       between(begin.Span, end.Span),
+	}
+	return node, nil
+}
+
+func (p *Parser) StmtList() ([]ast.Statement, error) {
+	var statements []ast.Statement
+
+	for {
+		node, err := p.Statement()
+		if err == nil && !isStatementEnd(p.currentToken.Type) && !isStatementStart(p.currentToken.Type) {
+			err = newParseError(p.currentToken, token.Semi, token.End)
+		}
+
+		recovered := err != nil
+		if recovered {
+			node, err = p.recover(err)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		statements = append(statements, node)
+
+		// Add the next statement to the list if the current token is a semicolon.
+		if p.currentToken.Type == token.Semi {
+			err := p.Consume(token.Semi)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// A statement directly following another statement is missing its semicolon. The
+		// error is only reported if it isn't a consequence of an error that was just recovered from.
+		if isStatementStart(p.currentToken.Type) {
+			if !recovered {
+				p.report(newParseError(p.currentToken, token.Semi, token.End))
+			}
+			continue
+		}
+
+		return statements, nil
+	}
+}
+
+func (p *Parser) Statement() (ast.Statement, error) {
+	switch p.currentToken.Type {
+	case token.Identifier:
+		variable, err := p.Variable()
+		if err != nil {
+			return nil, err
+		}
+		if p.currentToken.Type == token.Lbracket || p.currentToken.Type == token.Dot {
+			// Only a variable has selectors, so the statement has to be an assignment.
+			err = p.selectors(variable)
+			if err != nil {
+				return nil, err
+			}
+			return p.assignment(variable)
+		}
+		if p.currentToken.Type == token.Assign {
+			return p.assignment(variable)
+		}
+		return p.procedureCall(variable)
+	case token.Begin:
+		return p.CompoundStmt()
+	case token.If:
+		return p.IfStmt()
+	case token.While:
+		return p.WhileStmt()
+	case token.Repeat:
+		return p.RepeatStmt()
+	case token.For:
+		return p.ForStmt()
+	case token.Case:
+		return p.CaseStmt()
+	case token.With:
+		return p.WithStmt()
+	default:
+		return p.Empty()
+	}
+}
+
+// IfStmt parses a conditional statement. An ELSE belongs to the nearest IF without an ELSE,
+// the nested statement consumes it before the enclosing IF gets the chance to:
+//
+//	if_statement : IF expr THEN statement (ELSE statement)?
+func (p *Parser) IfStmt() (*ast.If, error) {
+	start := p.currentToken
+	err := p.Consume(token.If)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := p.Expr()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Then)
+	if err != nil {
+		return nil, err
+	}
+
+	then, err := p.Statement()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.If{
+		Condition: condition,
+		Then:      then,
+		Span:      between(start.Span, ast.SpanOf(then)),
+	}
+
+	if p.currentToken.Type == token.Else {
+		err = p.Consume(token.Else)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Else, err = p.Statement()
+		if err != nil {
+			return nil, err
+		}
+		node.Span = between(start.Span, ast.SpanOf(node.Else))
+	}
+	return node, nil
+}
+
+// CaseStmt parses a multi-way branch. The semicolon after the last branch is optional:
+//
+//	case_statement : CASE expr OF case_branch (SEMI case_branch)* SEMI? (ELSE statement_list)? END
+func (p *Parser) CaseStmt() (*ast.Case, error) {
+	start := p.currentToken
+	err := p.Consume(token.Case)
+	if err != nil {
+		return nil, err
+	}
+
+	expression, err := p.Expr()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Of)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.Case{
+		Expression: expression,
+	}
+
+	for {
+		branch, err := p.CaseBranch()
+		if err != nil {
+			return nil, err
+		}
+		node.Branches = append(node.Branches, branch)
+
+		if p.currentToken.Type != token.Semi {
+			break
+		}
+		err = p.Consume(token.Semi)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.currentToken.Type == token.Else || p.currentToken.Type == token.End {
+			break
+		}
+	}
+
+	if p.currentToken.Type == token.Else {
+		err = p.Consume(token.Else)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Else, err = p.StmtList()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	end := p.currentToken
+	err = p.Consume(token.End)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Span = between(start.Span, end.Span)
+	return node, nil
+}
+
+// CaseBranch parses the labels of a branch of a CASE statement and the statement they select:
+//
+//	case_branch : case_label (COMMA case_label)* COLON statement
+func (p *Parser) CaseBranch() (*ast.CaseBranch, error) {
+	var labels []*ast.CaseLabel
+
+	for {
+		label, err := p.CaseLabel()
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+
+		if p.currentToken.Type != token.Comma {
+			break
+		}
+		err = p.Consume(token.Comma)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := p.Consume(token.Colon)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := p.Statement()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.CaseBranch{
+		Labels:    labels,
+		Statement: statement,
+		Span:      between(labels[0].Span, ast.SpanOf(statement)),
+	}
+	return node, nil
+}
+
+// CaseLabel parses a constant or a range of constants:
+//
+//	case_label : constant (RANGE constant)?
+func (p *Parser) CaseLabel() (*ast.CaseLabel, error) {
+	low, err := p.Constant()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.CaseLabel{
+		Low:  low,
+		Span: ast.SpanOf(low),
+	}
+
+	if p.currentToken.Type == token.Range {
+		err = p.Consume(token.Range)
+		if err != nil {
+			return nil, err
+		}
+
+		node.High, err = p.Constant()
+		if err != nil {
+			return nil, err
+		}
+		node.Span = between(node.Span, ast.SpanOf(node.High))
+	}
+	return node, nil
+}
+
+// Constant parses a literal that can be used as a label, integers may be signed:
+//
+//	constant : (PLUS | MINUS)? INTEGER | TRUE | FALSE | STRING
+func (p *Parser) Constant() (ast.Expr, error) {
+	current := p.currentToken
+
+	switch current.Type {
+	case token.Add, token.Sub:
+		err := p.Consume(current.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		number := p.currentToken
+		err = p.Consume(token.Int)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &ast.UnaryOp{
+			Operator: current,
+			Expression: &ast.Num{
+				Token:  number,
+				Lexeme: number.Lexeme,
+				Span:   number.Span,
+			},
+			Span: between(current.Span, number.Span),
+		}
+		return node, nil
+	case token.Int:
+		err := p.Consume(token.Int)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &ast.Num{
+			Token:  current,
+			Lexeme: current.Lexeme,
+			Span:   current.Span,
+		}
+		return node, nil
+	case token.True, token.False:
+		err := p.Consume(current.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &ast.Boolean{
+			Token: current,
+			Value: current.Type == token.True,
+			Span:  current.Span,
+		}
+		return node, nil
+	case token.String:
+		err := p.Consume(token.String)
+		if err != nil {
+			return nil, err
+		}
+		return characterString(current), nil
+	}
 
-type Parser struct {
-	lexer        scanner.Scanner
-	currentToken token.Token
+	return nil, newParseError(current, token.Add, token.Sub, token.Int, token.True, token.False, token.String)
 }
 
-func (p *Parser) Consume(tokenType int) error {
-	if tokenType == p.currentToken.Type {
-		p.currentToken = p.lexer.Next()
-		return nil
+// WhileStmt parses a loop that checks its condition before every iteration:
+//
+//	while_statement : WHILE expr DO statement
+func (p *Parser) WhileStmt() (*ast.While, error) {
+	start := p.currentToken
+	err := p.Consume(token.While)
+	if err != nil {
+		return nil, err
 	}
-	return fmt.Errorf("unable to consume token %s", p.currentToken.Lexeme)
-}
 
-func (p *Parser) Program() ([]ast.Statement, error) {
-	statements, err := p.CompoundStmt()
+	condition, err := p.Expr()
 	if err != nil {
 		return nil, err
 	}
 
-	err = p.Consume(token.Dot)
+	err = p.Consume(token.Do)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.Statement()
 	if err != nil {
 		return nil, err
 	}
 
-	return statements, nil
+	node := &ast.While{
+		Condition: condition,
+		Body:      body,
+		Span:      between(start.Span, ast.SpanOf(body)),
+	}
+	return node, nil
 }
 
-func (p *Parser) CompoundStmt() ([]ast.Statement, error) {
-	err := p.Consume(token.Begin)
+// RepeatStmt parses a loop that checks its condition after every iteration:
+//
+//	repeat_statement : REPEAT statement_list UNTIL expr
+func (p *Parser) RepeatStmt() (*ast.Repeat, error) {
+	start := p.currentToken
+	err := p.Consume(token.Repeat)
 	if err != nil {
 		return nil, err
 	}
@@ -50,52 +1003,142 @@ func (p *Parser) CompoundStmt() ([]ast.Statement, error) {
 		return nil, err
 	}
 
-	err = p.Consume(token.End)
+	err = p.Consume(token.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := p.Expr()
 	if err != nil {
 		return nil, err
 	}
-	return statements, nil
+
+	node := &ast.Repeat{
+		Statements: statements,
+		Condition:  condition,
+		Span:       between(start.Span, ast.SpanOf(condition)),
+	}
+	return node, nil
 }
 
-func (p *Parser) StmtList() ([]ast.Statement, error) {
-	node, err := p.Statement()
+// ForStmt parses a loop over a range of integers:
+//
+//	for_statement : FOR variable ASSIGN expr (TO | DOWNTO) expr DO statement
+func (p *Parser) ForStmt() (*ast.For, error) {
+	start := p.currentToken
+	err := p.Consume(token.For)
 	if err != nil {
 		return nil, err
 	}
 
-	var statements []ast.Statement
-	statements = append(statements, node)
+	variable, err := p.Variable()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Assign)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := p.Expr()
+	if err != nil {
+		return nil, err
+	}
+
+	direction := p.currentToken
+	if direction.Type != token.To && direction.Type != token.Downto {
+		return nil, newParseError(direction, token.To, token.Downto)
+	}
+	err = p.Consume(direction.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := p.Expr()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Consume(token.Do)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.Statement()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.For{
+		Variable:  variable,
+		Start:     first,
+		Direction: direction,
+		End:       last,
+		Body:      body,
+		Span:      between(start.Span, ast.SpanOf(body)),
+	}
+	return node, nil
+}
+
+// WithStmt parses a statement that accesses the fields of RECORD variables by their names:
+//
+//	with_statement : WITH variable (COMMA variable)* DO statement
+func (p *Parser) WithStmt() (*ast.With, error) {
+	start := p.currentToken
+	err := p.Consume(token.With)
+	if err != nil {
+		return nil, err
+	}
 
-	// Add the next statement to the list if the current token is a semicolon.
-	for p.currentToken.Type == token.Semi {
-		err := p.Consume(token.Semi)
+	var variables []*ast.Variable
+	for {
+		variable, err := p.Variable()
 		if err != nil {
 			return nil, err
 		}
-		node, err := p.Statement()
+		err = p.selectors(variable)
 		if err != nil {
 			return nil, err
 		}
+		variables = append(variables, variable)
 
-		statements = append(statements, node)
+		if p.currentToken.Type != token.Comma {
+			break
+		}
+		err = p.Consume(token.Comma)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return statements, nil
-}
+	err = p.Consume(token.Do)
+	if err != nil {
+		return nil, err
+	}
 
-func (p *Parser) Statement() (ast.Statement, error) {
-	switch p.currentToken.Type {
-	case token.Identifier:
-		return p.AssignmentStmt()
-	case token.Begin:
-		return p.CompoundStmt()
-	default:
-		return p.Empty()
+	body, err := p.Statement()
+	if err != nil {
+		return nil, err
 	}
+
+	node := &ast.With{
+		Variables: variables,
+		Body:      body,
+		Span:      between(start.Span, ast.SpanOf(body)),
+	}
+	return node, nil
 }
 
 func (p *Parser) Empty() (*ast.Empty, error) {
-	return &ast.Empty{}, nil
+	start := p.currentToken.Span.Start
+	node := &ast.Empty{
+		Span: token.Span{
+			Start: start,
+			End:   start,
+		},
+	}
+	return node, nil
 }
 
 func (p *Parser) AssignmentStmt() (*ast.Assign, error) {
@@ -103,9 +1146,17 @@ func (p *Parser) AssignmentStmt() (*ast.Assign, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = p.selectors(left)
+	if err != nil {
+		return nil, err
+	}
+	return p.assignment(left)
+}
 
+// assignment parses the remainder of an assignment to the variable that was already parsed.
+func (p *Parser) assignment(left *ast.Variable) (*ast.Assign, error) {
 	operator := p.currentToken
-	err = p.Consume(token.Assign)
+	err := p.Consume(token.Assign)
 	if err != nil {
 		return nil, err
 	}
@@ -118,8 +1169,110 @@ func (p *Parser) AssignmentStmt() (*ast.Assign, error) {
 		Left:     left,
 		Operator: operator,
 		Right:    right,
+		Span:     between(left.Span, ast.SpanOf(right)),
+	}
+
+	return node, nil
+}
+
+// procedureCall parses the arguments of a call to the procedure that was already parsed, a
+// procedure without parameters is called without parentheses. The arguments can have a field
+// width, which only Write and WriteLn accept:
+//
+//	procedure_call : variable (LPAREN write_param (COMMA write_param)* RPAREN)?
+func (p *Parser) procedureCall(name *ast.Variable) (*ast.ProcedureCall, error) {
+	arguments, end, err := p.arguments(name.Span, p.writeParam)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.ProcedureCall{
+		Name:      name.Name,
+		Token:     name.Token,
+		Arguments: arguments,
+		Span:      between(name.Span, end),
+	}
+	return node, nil
+}
+
+// arguments parses the optional argument list of a call, every argument is parsed by the given
+// function. The span of the closing parenthesis is returned, or the given span when there is no
+// argument list.
+func (p *Parser) arguments(name token.Span, argument func() (ast.Expr, error)) ([]ast.Expr, token.Span, error) {
+	if p.currentToken.Type != token.Lparen {
+		return nil, name, nil
+	}
+
+	err := p.Consume(token.Lparen)
+	if err != nil {
+		return nil, name, err
+	}
+
+	var arguments []ast.Expr
+	for {
+		expression, err := argument()
+		if err != nil {
+			return nil, name, err
+		}
+		arguments = append(arguments, expression)
+
+		if p.currentToken.Type != token.Comma {
+			break
+		}
+		err = p.Consume(token.Comma)
+		if err != nil {
+			return nil, name, err
+		}
+	}
+
+	end := p.currentToken
+	err = p.Consume(token.Rparen)
+	if err != nil {
+		return nil, name, err
+	}
+	return arguments, end.Span, nil
+}
+
+// writeParam parses an argument of a procedure call, which is an expression that is optionally
+// followed by a field width and a number of decimals:
+//
+//	write_param : expr (COLON expr (COLON expr)?)?
+func (p *Parser) writeParam() (ast.Expr, error) {
+	expression, err := p.Expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.currentToken.Type != token.Colon {
+		return expression, nil
+	}
+
+	err = p.Consume(token.Colon)
+	if err != nil {
+		return nil, err
+	}
+	width, err := p.Expr()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ast.WriteParam{
+		Expression: expression,
+		Width:      width,
+		Span:       between(ast.SpanOf(expression), ast.SpanOf(width)),
+	}
+	if p.currentToken.Type != token.Colon {
+		return node, nil
 	}
 
+	err = p.Consume(token.Colon)
+	if err != nil {
+		return nil, err
+	}
+	node.Precision, err = p.Expr()
+	if err != nil {
+		return nil, err
+	}
+	node.Span = between(ast.SpanOf(expression), ast.SpanOf(node.Precision))
 	return node, nil
 }
 
@@ -129,7 +1282,9 @@ func (p *Parser) Variable() (*ast.Variable, error) {
 		Token: token.Token{
 			Type:   token.Identifier,
 			Lexeme: p.currentToken.Lexeme,
+			Span:   p.currentToken.Span,
 		},
+		Span: p.currentToken.Span,
 	}
 
 	err := p.Consume(token.Identifier)
@@ -140,13 +1295,102 @@ func (p *Parser) Variable() (*ast.Variable, error) {
 	return node, nil
 }
 
+// selectors parses the selectors of the variable that was already parsed, which select an
+// element of an ARRAY or a field of a RECORD. The indexes of every pair of brackets are
+// appended to the selectors of the variable:
+//
+//	variable : ID (LBRACKET expr (COMMA expr)* RBRACKET | DOT ID)*
+func (p *Parser) selectors(variable *ast.Variable) error {
+	for p.currentToken.Type == token.Lbracket || p.currentToken.Type == token.Dot {
+		if p.currentToken.Type == token.Dot {
+			err := p.Consume(token.Dot)
+			if err != nil {
+				return err
+			}
+
+			name := p.currentToken
+			err = p.Consume(token.Identifier)
+			if err != nil {
+				return err
+			}
+			variable.Selectors = append(variable.Selectors, &ast.Field{Name: name.Lexeme, Token: name, Span: name.Span})
+			variable.Span = between(variable.Span, name.Span)
+			continue
+		}
+
+		err := p.Consume(token.Lbracket)
+		if err != nil {
+			return err
+		}
+
+		for {
+			index, err := p.Expr()
+			if err != nil {
+				return err
+			}
+			variable.Selectors = append(variable.Selectors, index)
+
+			if p.currentToken.Type != token.Comma {
+				break
+			}
+			err = p.Consume(token.Comma)
+			if err != nil {
+				return err
+			}
+		}
+
+		end := p.currentToken
+		err = p.Consume(token.Rbracket)
+		if err != nil {
+			return err
+		}
+		variable.Span = between(variable.Span, end.Span)
+	}
+	return nil
+}
+
+// Expr parses a relational expression, the relational operators have the lowest precedence:
+//
+//	expr : simple_expr ((EQUAL | NOT_EQUAL | LESS | LESS_EQUAL | GREATER | GREATER_EQUAL) simple_expr)?
 func (p *Parser) Expr() (ast.Expr, error) {
+	node, err := p.SimpleExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if isRelationalOperator(p.currentToken.Type) {
+		operator := p.currentToken
+		err = p.Consume(p.currentToken.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		left := node
+		right, err := p.SimpleExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		node = &ast.BinOp{
+			Left:     left,
+			Operator: operator,
+			Right:    right,
+			Span:     between(ast.SpanOf(left), ast.SpanOf(right)),
+		}
+	}
+	return node, nil
+}
+
+// SimpleExpr parses the adding operators, OR has the same precedence as addition:
+//
+//	simple_expr : term ((PLUS | MINUS | OR) term)*
+func (p *Parser) SimpleExpr() (ast.Expr, error) {
 	node, err := p.Term()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.currentToken.Type == token.Add || p.currentToken.Type == token.Sub {
+	for p.currentToken.Type == token.Add || p.currentToken.Type == token.Sub || p.currentToken.Type == token.Or {
 		operator := p.currentToken
 		err = p.Consume(p.currentToken.Type)
 		if err != nil {
@@ -165,6 +1409,7 @@ func (p *Parser) Expr() (ast.Expr, error) {
 			Left:     left,
 			Operator: operator,
 			Right:    right,
+			Span:     between(ast.SpanOf(left), ast.SpanOf(right)),
 		}
 	}
 	return node, nil
@@ -176,7 +1421,8 @@ func (p *Parser) Term() (ast.Expr, error) {
 		return nil, err
 	}
 
-	for p.currentToken.Type == token.Mul || p.currentToken.Type == token.Div {
+	for p.currentToken.Type == token.Mul || p.currentToken.Type == token.Div ||
+		p.currentToken.Type == token.IntDiv || p.currentToken.Type == token.Mod || p.currentToken.Type == token.And {
 		operator := p.currentToken
 		err = p.Consume(p.currentToken.Type)
 		if err != nil {
@@ -193,6 +1439,7 @@ func (p *Parser) Term() (ast.Expr, error) {
 			Left:     left,
 			Operator: operator,
 			Right:    right,
+			Span:     between(ast.SpanOf(left), ast.SpanOf(right)),
 		}
 	}
 	return node, nil
@@ -214,6 +1461,7 @@ func (p *Parser) Factor() (ast.Expr, error) {
 			return nil, err
 		}
 		node.Expression = factor
+		node.Span = between(node.Operator.Span, ast.SpanOf(factor))
 		return node, nil
 	case token.Sub:
 		node := &ast.UnaryOp{
@@ -228,13 +1476,48 @@ func (p *Parser) Factor() (ast.Expr, error) {
 			return nil, err
 		}
 		node.Expression = factor
+		node.Span = between(node.Operator.Span, ast.SpanOf(factor))
 		return node, nil
-	case token.Int:
+	case token.Not:
+		node := &ast.UnaryOp{
+			Operator: p.currentToken,
+		}
+		err := p.Consume(token.Not)
+		if err != nil {
+			return nil, err
+		}
+		factor, err := p.Factor()
+		if err != nil {
+			return nil, err
+		}
+		node.Expression = factor
+		node.Span = between(node.Operator.Span, ast.SpanOf(factor))
+		return node, nil
+	case token.True, token.False:
+		node := &ast.Boolean{
+			Token: p.currentToken,
+			Value: p.currentToken.Type == token.True,
+			Span:  p.currentToken.Span,
+		}
+		err := p.Consume(p.currentToken.Type)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
+	case token.Int, token.Real:
 		node := &ast.Num{
 			Token:  p.currentToken,
 			Lexeme: p.currentToken.Lexeme,
+			Span:   p.currentToken.Span,
 		}
-		err := p.Consume(token.Int)
+		err := p.Consume(p.currentToken.Type)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
+	case token.String:
+		node := characterString(p.currentToken)
+		err := p.Consume(token.String)
 		if err != nil {
 			return nil, err
 		}
@@ -255,9 +1538,135 @@ func (p *Parser) Factor() (ast.Expr, error) {
 		}
 		return expr, nil
 	case token.Identifier:
-		return p.Variable()
+		variable, err := p.Variable()
+		if err != nil {
+			return nil, err
+		}
+		if p.currentToken.Type == token.Lbracket || p.currentToken.Type == token.Dot {
+			err = p.selectors(variable)
+			if err != nil {
+				return nil, err
+			}
+			return variable, nil
+		}
+		if p.currentToken.Type != token.Lparen {
+			return variable, nil
+		}
+
+		arguments, end, err := p.arguments(variable.Span, p.Expr)
+		if err != nil {
+			return nil, err
+		}
+		node := &ast.FunctionCall{
+			Name:      variable.Name,
+			Token:     variable.Token,
+			Arguments: arguments,
+			Span:      between(variable.Span, end),
+		}
+		return node, nil
+	}
+	return nil, newParseError(p.currentToken, token.Add, token.Sub, token.Not, token.Int, token.Real,
+		token.True, token.False, token.String, token.Lparen, token.Identifier)
+}
+
+// characterString creates the literal of a character string token, a string of a single
+// character is a CHAR.
+func characterString(current token.Token) ast.Expr {
+	value := scanner.Unquote(current.Lexeme)
+	if len(value) == 1 {
+		return &ast.Char{Token: current, Value: value[0], Span: current.Span}
+	}
+	return &ast.String{Token: current, Value: value, Span: current.Span}
+}
+
+// recover records the syntax error and skips tokens until a token is found at which parsing
+// can be resumed. The skipped tokens are replaced by an ast.Error node. Errors other than
+// syntax errors are returned as is.
+func (p *Parser) recover(err error) (*ast.Error, error) {
+	parseError, ok := err.(*ParseError)
+	if !ok {
+		return nil, err
+	}
+	p.report(parseError)
+
+	start := parseError.Span.Start
+	p.synchronize()
+
+	node := &ast.Error{
+		Span: token.Span{
+			Start: start,
+			End:   p.currentToken.Span.Start,
+		},
+	}
+	return node, nil
+}
+
+// synchronize skips tokens until a semicolon, END, UNTIL, the end of the program or a keyword
+// that starts a statement is reached.
+func (p *Parser) synchronize() {
+	for {
+		switch p.currentToken.Type {
+		case token.Semi, token.End, token.Until, token.Dot, token.EOF,
+			token.Begin, token.If, token.While, token.Repeat, token.For, token.Case, token.With:
+			return
+		}
+		p.currentToken = p.lexer.Next()
+	}
+}
+
+// fail adds the error to the syntax errors that were recovered from. The list of all syntax
+// errors is returned, errors other than syntax errors are returned as is.
+func (p *Parser) fail(err error) error {
+	parseError, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	p.report(parseError)
+	return p.errors
+}
+
+// report adds the error to the syntax errors that were recovered from. An error at the position
+// of the previous error is a consequence of recovering from that error and isn't added.
+func (p *Parser) report(parseError *ParseError) {
+	if count := len(p.errors); count > 0 && p.errors[count-1].Span.Start == parseError.Span.Start {
+		return
+	}
+	p.errors = append(p.errors, parseError)
+}
+
+// isRelationalOperator reports whether the token type is one of the relational operators.
+func isRelationalOperator(tokenType int) bool {
+	switch tokenType {
+	case token.Equal, token.NotEqual, token.Less, token.LessEqual, token.Greater, token.GreaterEqual:
+		return true
+	}
+	return false
+}
+
+// isStatementEnd reports whether the token type can directly follow a statement.
+func isStatementEnd(tokenType int) bool {
+	switch tokenType {
+	case token.Semi, token.End, token.Until, token.Dot, token.EOF:
+		return true
+	}
+	return false
+}
+
+// isStatementStart reports whether a statement can start with the token type.
+func isStatementStart(tokenType int) bool {
+	switch tokenType {
+	case token.Identifier, token.Begin, token.If, token.While, token.Repeat, token.For, token.Case, token.With:
+		return true
+	}
+	return false
+}
+
+// between creates a span ranging from the start of the first span to the end of the last span.
+func between(first token.Span, last token.Span) token.Span {
+	return token.Span{
+		Start: first.Start,
+		End:   last.End,
 	}
-	return nil, endReachedError
 }
 
 // New creates the struct Parser.
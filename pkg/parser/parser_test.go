@@ -699,6 +699,94 @@ func TestParser_Factor_Variable(t *testing.T) {
 	assert.Equal(t, expected, variable)
 }
 
+func TestParser_Factor_IndexedVariable(t *testing.T) {
+	// "a[1]"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+	tokens := []token.Token{
+		{Type: token.Identifier, Lexeme: "a"},
+		{Type: token.Lbracket, Lexeme: "["},
+		{Type: token.Int, Lexeme: "1"},
+		{Type: token.Rbracket, Lexeme: "]"},
+		{Type: token.EOF},
+	}
+	for _, current := range tokens {
+		m.
+			EXPECT().
+			Next().
+			Return(current)
+	}
+
+	parser := parser.New(m)
+
+	expected := &ast.Variable{
+		Name: "a",
+		Token: token.Token{
+			Type:   token.Identifier,
+			Lexeme: "a",
+		},
+		Selectors: []ast.Expr{
+			&ast.Num{
+				Token:  tokens[2],
+				Lexeme: "1",
+			},
+		},
+	}
+
+	expr, err := parser.Factor()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, expr)
+}
+
+func TestParser_Factor_FieldOfVariable(t *testing.T) {
+	// "p.x[1]"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+	tokens := []token.Token{
+		{Type: token.Identifier, Lexeme: "p"},
+		{Type: token.Dot, Lexeme: "."},
+		{Type: token.Identifier, Lexeme: "x"},
+		{Type: token.Lbracket, Lexeme: "["},
+		{Type: token.Int, Lexeme: "1"},
+		{Type: token.Rbracket, Lexeme: "]"},
+		{Type: token.EOF},
+	}
+	for _, current := range tokens {
+		m.
+			EXPECT().
+			Next().
+			Return(current)
+	}
+
+	parser := parser.New(m)
+
+	expected := &ast.Variable{
+		Name: "p",
+		Token: token.Token{
+			Type:   token.Identifier,
+			Lexeme: "p",
+		},
+		Selectors: []ast.Expr{
+			&ast.Field{
+				Name:  "x",
+				Token: tokens[2],
+			},
+			&ast.Num{
+				Token:  tokens[4],
+				Lexeme: "1",
+			},
+		},
+	}
+
+	expr, err := parser.Factor()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, expr)
+}
+
 func TestParser_Statement_AssignmentStmt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1058,8 +1146,150 @@ func TestParser_Program(t *testing.T) {
 		After(before).
 		AnyTimes()
 
-	statements, err := parser.Program()
+	program, err := parser.Program()
 	assert.Nil(t, err)
 
-	assert.Equal(t, expected, statements)
+	assert.Equal(t, "", program.Name)
+	assert.Empty(t, program.Block.Declarations)
+	assert.Equal(t, expected, program.Block.Compound.Statements)
+}
+
+func TestParser_TypeSpec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+
+	returnValue := token.Token{
+		Type:   token.Identifier,
+		Lexeme: "real",
+	}
+
+	m.
+		EXPECT().
+		Next().
+		Return(returnValue)
+
+	parser := parser.New(m)
+
+	returnValue = token.Token{
+		Type:   token.Semi,
+		Lexeme: ";",
+	}
+
+	m.
+		EXPECT().
+		Next().
+		Return(returnValue).
+		AnyTimes()
+
+	expected := &ast.TypeSpec{
+		Token: token.Token{
+			Type:   token.Identifier,
+			Lexeme: "real",
+		},
+		Name: "real",
+	}
+
+	typeSpec, err := parser.TypeSpec()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, typeSpec)
+
+	_, err = parser.TypeSpec()
+	assert.NotNil(t, err)
+}
+
+func TestParser_TypeSpec_Array(t *testing.T) {
+	// "ARRAY[1..10] OF INTEGER"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+	tokens := []token.Token{
+		{Type: token.Array, Lexeme: "array"},
+		{Type: token.Lbracket, Lexeme: "["},
+		{Type: token.Int, Lexeme: "1"},
+		{Type: token.Range, Lexeme: ".."},
+		{Type: token.Int, Lexeme: "10"},
+		{Type: token.Rbracket, Lexeme: "]"},
+		{Type: token.Of, Lexeme: "of"},
+		{Type: token.Identifier, Lexeme: "integer"},
+		{Type: token.Semi, Lexeme: ";"},
+	}
+	for _, current := range tokens {
+		m.
+			EXPECT().
+			Next().
+			Return(current)
+	}
+
+	parser := parser.New(m)
+
+	expected := &ast.TypeSpec{
+		Token: tokens[0],
+		Name:  "array",
+		Ranges: []*ast.Subrange{
+			{
+				Low:  &ast.Num{Token: tokens[2], Lexeme: "1"},
+				High: &ast.Num{Token: tokens[4], Lexeme: "10"},
+			},
+		},
+		Element: &ast.TypeSpec{
+			Token: tokens[7],
+			Name:  "integer",
+		},
+	}
+
+	typeSpec, err := parser.TypeSpec()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, typeSpec)
+}
+
+func TestParser_TypeSpec_Record(t *testing.T) {
+	// "RECORD x, y : INTEGER; p : point; END"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_scanner.NewMockScanner(ctrl)
+	tokens := []token.Token{
+		{Type: token.Record, Lexeme: "record"},
+		{Type: token.Identifier, Lexeme: "x"},
+		{Type: token.Comma, Lexeme: ","},
+		{Type: token.Identifier, Lexeme: "y"},
+		{Type: token.Colon, Lexeme: ":"},
+		{Type: token.Identifier, Lexeme: "integer"},
+		{Type: token.Semi, Lexeme: ";"},
+		{Type: token.Identifier, Lexeme: "p"},
+		{Type: token.Colon, Lexeme: ":"},
+		{Type: token.Identifier, Lexeme: "point"},
+		{Type: token.Semi, Lexeme: ";"},
+		{Type: token.End, Lexeme: "end"},
+		{Type: token.Semi, Lexeme: ";"},
+	}
+	for _, current := range tokens {
+		m.
+			EXPECT().
+			Next().
+			Return(current)
+	}
+
+	parser := parser.New(m)
+
+	integer := &ast.TypeSpec{Token: tokens[5], Name: "integer"}
+	expected := &ast.TypeSpec{
+		Token: tokens[0],
+		Name:  "record",
+		Fields: []*ast.VarDecl{
+			{Variable: &ast.Variable{Name: "x", Token: tokens[1]}, Type: integer},
+			{Variable: &ast.Variable{Name: "y", Token: tokens[3]}, Type: integer},
+			{
+				Variable: &ast.Variable{Name: "p", Token: tokens[7]},
+				Type:     &ast.TypeSpec{Token: tokens[9], Name: "point"},
+			},
+		},
+	}
+
+	typeSpec, err := parser.TypeSpec()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, typeSpec)
 }
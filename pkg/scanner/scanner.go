@@ -38,8 +38,43 @@ package scanner
 import (
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
 	"strings"
+	"unicode/utf8"
 )
 
+// keywords maps the reserved words of the language to their token type.
+var keywords = map[string]int{
+	"begin":     token.Begin,
+	"end":       token.End,
+	"program":   token.Program,
+	"var":       token.Var,
+	"div":       token.IntDiv,
+	"mod":       token.Mod,
+	"and":       token.And,
+	"or":        token.Or,
+	"not":       token.Not,
+	"true":      token.True,
+	"false":     token.False,
+	"if":        token.If,
+	"then":      token.Then,
+	"else":      token.Else,
+	"while":     token.While,
+	"do":        token.Do,
+	"repeat":    token.Repeat,
+	"until":     token.Until,
+	"for":       token.For,
+	"to":        token.To,
+	"downto":    token.Downto,
+	"case":      token.Case,
+	"of":        token.Of,
+	"procedure": token.Procedure,
+	"function":  token.Function,
+	"const":     token.Const,
+	"array":     token.Array,
+	"type":      token.Type,
+	"record":    token.Record,
+	"with":      token.With,
+}
+
 type Scanner interface {
 	Next() token.Token
 	Peek() string
@@ -51,144 +86,378 @@ type scanner struct {
 	Stream   string
 	Position int
 	Current  string
+
+	// File is the name of the file the input stream originates from, it is attached
+	// to the position of every token.
+	File   string
+	Line   int
+	Column int
+
+	// Comments contains the comments that were skipped so far, in order of appearance. Tools
+	// that have to preserve comments can retrieve them as trivia after scanning.
+	Comments []token.Token
 }
 
 // Next returns the next token from the input stream.
 func (s *scanner) Next() token.Token {
 	for s.Current != "" {
-		if s.Current == " " || s.Current == "\n" {
+		if isWhitespace(s.Current) {
 			s.Advance()
 			continue
 		}
 
+		if s.Current == "{" || s.Current == "(" && s.Peek() == "*" || s.Current == "/" && s.Peek() == "/" {
+			comment := s.comment()
+			if comment.Type == token.UnterminatedComment {
+				return comment
+			}
+			s.Comments = append(s.Comments, comment)
+			continue
+		}
+
+		start := s.position()
+
 		if s.Current >= "a" && s.Current <= "z" || s.Current >= "A" && s.Current <= "Z" {
 			var sb strings.Builder
 			sb.WriteString(s.Current)
 
-			for s.Peek() >= "a" && s.Peek() <= "z" || s.Peek() >= "A" && s.Peek() <= "Z" {
-				if strings.ToLower(sb.String()) == "begin" || strings.ToLower(sb.String()) == "end" {
-					break
-				}
+			for s.Peek() >= "a" && s.Peek() <= "z" || s.Peek() >= "A" && s.Peek() <= "Z" || s.Peek() >= "0" && s.Peek() <= "9" {
 				sb.WriteString(s.Peek())
 				s.Advance()
 			}
 
 			s.Advance()
 			result := strings.ToLower(sb.String())
-			var newToken token.Token
-			switch result {
-			case "begin":
-				newToken = token.Token{
-					Type: token.Begin,
-				}
-				break
-			case "end":
-				newToken = token.Token{
-					Type: token.End,
-				}
-				break
-			default:
-				newToken = token.Token{
-					Type: token.Identifier,
-				}
-				break
+			tokenType := token.Identifier
+			if keyword, ok := keywords[result]; ok {
+				tokenType = keyword
 			}
 
-			newToken.Lexeme = result
-			return newToken
+			return s.token(tokenType, result, start)
 		}
 
 		if s.Current == ":" && s.Peek() == "=" {
 			s.Advance()
 			s.Advance()
-			return token.Token{
-				Type:   token.Assign,
-				Lexeme: ":=",
-			}
+			return s.token(token.Assign, ":=", start)
+		}
+
+		if s.Current == ":" {
+			s.Advance()
+			return s.token(token.Colon, ":", start)
+		}
+
+		if s.Current == "=" {
+			s.Advance()
+			return s.token(token.Equal, "=", start)
+		}
+
+		if s.Current == "<" && s.Peek() == ">" {
+			s.Advance()
+			s.Advance()
+			return s.token(token.NotEqual, "<>", start)
+		}
+
+		if s.Current == "<" && s.Peek() == "=" {
+			s.Advance()
+			s.Advance()
+			return s.token(token.LessEqual, "<=", start)
+		}
+
+		if s.Current == "<" {
+			s.Advance()
+			return s.token(token.Less, "<", start)
+		}
+
+		if s.Current == ">" && s.Peek() == "=" {
+			s.Advance()
+			s.Advance()
+			return s.token(token.GreaterEqual, ">=", start)
+		}
+
+		if s.Current == ">" {
+			s.Advance()
+			return s.token(token.Greater, ">", start)
+		}
+
+		if s.Current == "," {
+			s.Advance()
+			return s.token(token.Comma, ",", start)
+		}
+
+		if s.Current == "." && s.Peek() == "." {
+			s.Advance()
+			s.Advance()
+			return s.token(token.Range, "..", start)
 		}
 
 		if s.Current == "." {
 			s.Advance()
-			return token.Token{
-				Type:   token.Dot,
-				Lexeme: ".",
-			}
+			return s.token(token.Dot, ".", start)
 		}
 
 		if s.Current == ";" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Semi,
-				Lexeme: ";",
-			}
+			return s.token(token.Semi, ";", start)
 		}
 
 		if s.Current == "+" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Add,
-				Lexeme: "+",
-			}
+			return s.token(token.Add, "+", start)
 		}
 
 		if s.Current == "-" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Sub,
-				Lexeme: "-",
-			}
+			return s.token(token.Sub, "-", start)
 		}
 
 		if s.Current == "*" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Mul,
-				Lexeme: "*",
-			}
+			return s.token(token.Mul, "*", start)
 		}
 
 		if s.Current == "/" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Div,
-				Lexeme: "/",
-			}
+			return s.token(token.Div, "/", start)
 		}
 
 		if s.Current == "(" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Lparen,
-				Lexeme: "(",
-			}
+			return s.token(token.Lparen, "(", start)
 		}
 
 		if s.Current == ")" {
 			s.Advance()
-			return token.Token{
-				Type:   token.Rparen,
-				Lexeme: ")",
+			return s.token(token.Rparen, ")", start)
+		}
+
+		if s.Current == "[" {
+			s.Advance()
+			return s.token(token.Lbracket, "[", start)
+		}
+
+		if s.Current == "]" {
+			s.Advance()
+			return s.token(token.Rbracket, "]", start)
+		}
+
+		if isDigit(s.Current) {
+			return s.number(start)
+		}
+
+		if s.Current == "'" || s.Current == "#" && isDigit(s.Peek()) {
+			return s.characterString(start)
+		}
+
+		// Any other character isn't part of the language. Multi-byte characters are
+		// returned as a whole, so the lexeme remains valid UTF-8.
+		_, size := utf8.DecodeRuneInString(s.Stream[s.Position:])
+		lexeme := s.Stream[s.Position : s.Position+size]
+		for i := 0; i < size; i++ {
+			s.Advance()
+		}
+		return s.token(token.Illegal, lexeme, start)
+	}
+	return s.token(token.EOF, "", s.position())
+}
+
+// comment scans a comment enclosed by { and }, (* and *), or a line comment starting with //.
+// The lexeme of the returned token contains the comment including its delimiters. A comment
+// that isn't closed before the end of the input stream results in an UnterminatedComment token.
+func (s *scanner) comment() token.Token {
+	start := s.position()
+	begin := s.Position
+
+	if s.Current == "/" {
+		for s.Current != "" && s.Current != "\n" {
+			s.Advance()
+		}
+		return s.token(token.Comment, strings.TrimRight(s.Stream[begin:s.Position], "\r"), start)
+	}
+
+	brace := s.Current == "{"
+	s.Advance()
+	if !brace {
+		s.Advance()
+	}
+
+	for s.Current != "" {
+		if brace && s.Current == "}" {
+			s.Advance()
+			return s.token(token.Comment, s.Stream[begin:s.Position], start)
+		}
+		if !brace && s.Current == "*" && s.Peek() == ")" {
+			s.Advance()
+			s.Advance()
+			return s.token(token.Comment, s.Stream[begin:s.Position], start)
+		}
+		s.Advance()
+	}
+	return s.token(token.UnterminatedComment, s.Stream[begin:s.Position], start)
+}
+
+// number scans an integer or a real number. A real number contains a fraction, an exponent
+// or both, for instance 3.14, 1E10 or 1.5e-3. The digits of a fraction are mandatory, so the
+// dot in "1." isn't part of the number.
+func (s *scanner) number(start token.Position) token.Token {
+	begin := s.Position
+	tokenType := token.Int
+
+	for isDigit(s.Current) {
+		s.Advance()
+	}
+
+	if s.Current == "." && isDigit(s.Peek()) {
+		tokenType = token.Real
+		s.Advance()
+		for isDigit(s.Current) {
+			s.Advance()
+		}
+	}
+
+	if s.Current == "e" || s.Current == "E" {
+		// The exponent is only part of the number if it contains digits.
+		digits := s.Peek()
+		if digits == "+" || digits == "-" {
+			digits = s.peekAt(2)
+		}
+
+		if isDigit(digits) {
+			tokenType = token.Real
+			s.Advance()
+			if s.Current == "+" || s.Current == "-" {
+				s.Advance()
+			}
+			for isDigit(s.Current) {
+				s.Advance()
 			}
 		}
+	}
 
-		if s.Current >= "0" && s.Current <= "9" {
-			var sb strings.Builder
-			sb.WriteString(s.Current)
-			for s.Peek() >= "0" && s.Peek() <= "9" {
-				sb.WriteString(s.Peek())
+	return s.token(tokenType, s.Stream[begin:s.Position], start)
+}
+
+// characterString scans a character string, which consists of quoted strings and character
+// codes without anything in between, for instance 'line'#13#10. A quote inside a quoted
+// string is written as two quotes. A quoted string that isn't closed on the same line
+// results in an UnterminatedString token, a character code above 255 results in an Illegal
+// token.
+func (s *scanner) characterString(start token.Position) token.Token {
+	begin := s.Position
+
+	for {
+		switch {
+		case s.Current == "'":
+			s.Advance()
+			for {
+				if s.Current == "" || s.Current == "\n" || s.Current == "\r" {
+					return s.token(token.UnterminatedString, s.Stream[begin:s.Position], start)
+				}
+				if s.Current == "'" && s.Peek() != "'" {
+					s.Advance()
+					break
+				}
+				if s.Current == "'" {
+					s.Advance()
+				}
 				s.Advance()
 			}
+		case s.Current == "#" && isDigit(s.Peek()):
 			s.Advance()
-			return token.Token{
-				Type:   token.Int,
-				Lexeme: sb.String(),
+			code := 0
+			for isDigit(s.Current) {
+				code = code*10 + int(s.Current[0]-'0')
+				if code > 255 {
+					for isDigit(s.Current) {
+						s.Advance()
+					}
+					return s.token(token.Illegal, s.Stream[begin:s.Position], start)
+				}
+				s.Advance()
+			}
+		default:
+			return s.token(token.String, s.Stream[begin:s.Position], start)
+		}
+	}
+}
+
+// Unquote returns the characters of a character string as scanned by the scanner. The quotes
+// are removed, doubled quotes are replaced by a single quote and character codes are replaced
+// by the character with the code, for instance 'Hi'#33 results in "Hi!".
+func Unquote(lexeme string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(lexeme); {
+		switch lexeme[i] {
+		case '\'':
+			i++
+			for i < len(lexeme) {
+				if lexeme[i] == '\'' && i+1 < len(lexeme) && lexeme[i+1] == '\'' {
+					sb.WriteByte('\'')
+					i += 2
+					continue
+				}
+				if lexeme[i] == '\'' {
+					i++
+					break
+				}
+				sb.WriteByte(lexeme[i])
+				i++
 			}
+		case '#':
+			i++
+			code := 0
+			for i < len(lexeme) && lexeme[i] >= '0' && lexeme[i] <= '9' {
+				code = code*10 + int(lexeme[i]-'0')
+				i++
+			}
+			sb.WriteByte(byte(code))
+		default:
+			i++
 		}
+	}
+	return sb.String()
+}
+
+// isDigit reports whether the character is a decimal digit.
+func isDigit(character string) bool {
+	return character >= "0" && character <= "9"
+}
 
+// isWhitespace reports whether the character separates tokens without being a token itself.
+func isWhitespace(character string) bool {
+	switch character {
+	case " ", "\t", "\n", "\r", "\f", "\v":
+		return true
 	}
+	return false
+}
+
+// token creates a token of the given type whose span starts at start and ends at the
+// current position of the input stream.
+func (s *scanner) token(tokenType int, lexeme string, start token.Position) token.Token {
 	return token.Token{
-		Type:   token.EOF,
-		Lexeme: "",
+		Type:   tokenType,
+		Lexeme: lexeme,
+		Span:   s.span(start),
+	}
+}
+
+// span creates a span from start up to the current position of the input stream.
+func (s *scanner) span(start token.Position) token.Span {
+	return token.Span{
+		Start: start,
+		End:   s.position(),
+	}
+}
+
+// position returns the current position of the input stream.
+func (s *scanner) position() token.Position {
+	return token.Position{
+		File:   s.File,
+		Offset: s.Position,
+		Line:   s.Line,
+		Column: s.Column,
 	}
 }
 
@@ -201,12 +470,33 @@ func (s *scanner) Peek() string {
 	return string(s.Stream[s.Position+1])
 }
 
+// peekAt retrieves the lexeme the given number of characters ahead of the current position.
+func (s *scanner) peekAt(distance int) string {
+	if s.Position+distance >= len(s.Stream) {
+		return ""
+	}
+	return string(s.Stream[s.Position+distance])
+}
+
 // Advance changes the current position and assigns the new position to s.Current.
+// Once the end of the input stream is reached, the position points just past the
+// last character and s.Current is empty.
 func (s *scanner) Advance() {
-	if s.Position+1 >= len(s.Stream) {
+	if s.Current == "" {
+		return
+	}
+
+	if s.Current == "\n" {
+		s.Line++
+		s.Column = 1
+	} else {
+		s.Column++
+	}
+
+	s.Position++
+	if s.Position >= len(s.Stream) {
 		s.Current = ""
 	} else {
-		s.Position++
 		s.Current = string(s.Stream[s.Position])
 	}
 }
@@ -215,6 +505,10 @@ func (s *scanner) Advance() {
 func New(stream string) (*scanner, error) {
 	scanner := &scanner{}
 	scanner.Stream = stream
-	scanner.Current = string(stream[0])
+	if len(stream) > 0 {
+		scanner.Current = string(stream[0])
+	}
+	scanner.Line = 1
+	scanner.Column = 1
 	return scanner, nil
 }
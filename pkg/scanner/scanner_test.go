@@ -4,11 +4,29 @@ import (
 	"github.com/njirem95/simple-pascal/pkg/scanner"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
 )
 
 var unexpectedTokenError = "unexpected token"
 
+// span creates the span of a lexeme with the given length, starting at the given offset
+// of a single line input stream.
+func span(offset int, length int) token.Span {
+	return token.Span{
+		Start: token.Position{
+			Offset: offset,
+			Line:   1,
+			Column: offset + 1,
+		},
+		End: token.Position{
+			Offset: offset + length,
+			Line:   1,
+			Column: offset + length + 1,
+		},
+	}
+}
+
 func TestScanner_Advance(t *testing.T) {
 	input := "1+2"
 	expected := [4]string{"1", "+", "2", ""}
@@ -27,26 +45,32 @@ func TestScanner_Next_AddSub(t *testing.T) {
 		{
 			Type:   token.Int,
 			Lexeme: "1",
+			Span:   span(0, 1),
 		},
 		{
 			Type:   token.Add,
 			Lexeme: "+",
+			Span:   span(2, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "2",
+			Span:   span(5, 1),
 		},
 		{
 			Type:   token.Sub,
 			Lexeme: "-",
+			Span:   span(7, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "1",
+			Span:   span(9, 1),
 		},
 		{
 			Type:   token.EOF,
 			Lexeme: "",
+			Span:   span(10, 0),
 		},
 	}
 
@@ -64,42 +88,52 @@ func TestScanner_Next_MulDiv(t *testing.T) {
 		{
 			Type:   token.Int,
 			Lexeme: "5",
+			Span:   span(0, 1),
 		},
 		{
 			Type:   token.Mul,
 			Lexeme: "*",
+			Span:   span(2, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "2",
+			Span:   span(4, 1),
 		},
 		{
 			Type:   token.Div,
 			Lexeme: "/",
+			Span:   span(6, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "3",
+			Span:   span(8, 1),
 		},
 		{
 			Type:   token.Add,
 			Lexeme: "+",
+			Span:   span(10, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "8",
+			Span:   span(12, 1),
 		},
 		{
 			Type:   token.Sub,
 			Lexeme: "-",
+			Span:   span(14, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "5",
+			Span:   span(16, 1),
 		},
 		{
 			Type:   token.EOF,
 			Lexeme: "",
+			Span:   span(17, 0),
 		},
 	}
 
@@ -117,74 +151,92 @@ func TestScanner_Next_WithParentheses(t *testing.T) {
 		{
 			Type:   token.Lparen,
 			Lexeme: "(",
+			Span:   span(0, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "10",
+			Span:   span(1, 2),
 		},
 		{
 			Type:   token.Add,
 			Lexeme: "+",
+			Span:   span(4, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "5",
+			Span:   span(6, 1),
 		},
 		{
 			Type:   token.Rparen,
 			Lexeme: ")",
+			Span:   span(7, 1),
 		},
 		{
 			Type:   token.Mul,
 			Lexeme: "*",
+			Span:   span(9, 1),
 		},
 		{
 			Type:   token.Lparen,
 			Lexeme: "(",
+			Span:   span(11, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "9",
+			Span:   span(12, 1),
 		},
 		{
 			Type:   token.Div,
 			Lexeme: "/",
+			Span:   span(14, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "2",
+			Span:   span(16, 1),
 		},
 		{
 			Type:   token.Mul,
 			Lexeme: "*",
+			Span:   span(18, 1),
 		},
 		{
 			Type:   token.Lparen,
 			Lexeme: "(",
+			Span:   span(20, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "5",
+			Span:   span(21, 1),
 		},
 		{
 			Type:   token.Sub,
 			Lexeme: "-",
+			Span:   span(23, 1),
 		},
 		{
 			Type:   token.Int,
 			Lexeme: "3",
+			Span:   span(25, 1),
 		},
 		{
 			Type:   token.Rparen,
 			Lexeme: ")",
+			Span:   span(26, 1),
 		},
 		{
 			Type:   token.Rparen,
 			Lexeme: ")",
+			Span:   span(27, 1),
 		},
 		{
 			Type:   token.EOF,
 			Lexeme: "",
+			Span:   span(28, 0),
 		},
 	}
 
@@ -201,6 +253,7 @@ func TestScanner_Next_Identifier(t *testing.T) {
 	expected := token.Token{
 		Type:   token.Identifier,
 		Lexeme: "cool",
+		Span:   span(0, 4),
 	}
 
 	lexer, err := scanner.New(input)
@@ -215,6 +268,7 @@ func TestScanner_Next_BeginAndEndToken(t *testing.T) {
 	expected := token.Token{
 		Type:   token.Begin,
 		Lexeme: "begin",
+		Span:   span(0, 5),
 	}
 
 	lexer, err := scanner.New(input)
@@ -227,11 +281,39 @@ func TestScanner_Next_BeginAndEndToken(t *testing.T) {
 	expected = token.Token{
 		Type:   token.End,
 		Lexeme: "end",
+		Span:   span(6, 3),
 	}
 
 	assert.Equal(t, expected, next)
 }
 
+func TestScanner_Next_IdentifierStartingWithKeyword(t *testing.T) {
+	expected := []token.Token{
+		{
+			Type:   token.Identifier,
+			Lexeme: "endx",
+			Span:   span(0, 4),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "beginning",
+			Span:   span(5, 9),
+		},
+		{
+			Type:   token.End,
+			Lexeme: "end",
+			Span:   span(15, 3),
+		},
+	}
+
+	lexer, err := scanner.New("endx beginning END")
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
 func TestScanner_Next_Variable(t *testing.T) {
 	input := "aAp := 12"
 	lexer, err := scanner.New(input)
@@ -240,6 +322,7 @@ func TestScanner_Next_Variable(t *testing.T) {
 	expected := token.Token{
 		Type:   token.Identifier,
 		Lexeme: "aap",
+		Span:   span(0, 3),
 	}
 	next := lexer.Next()
 	assert.Equal(t, expected, next)
@@ -247,6 +330,7 @@ func TestScanner_Next_Variable(t *testing.T) {
 	expected = token.Token{
 		Type:   token.Assign,
 		Lexeme: ":=",
+		Span:   span(4, 2),
 	}
 	next = lexer.Next()
 
@@ -262,6 +346,7 @@ func TestScanner_Next_Semi(t *testing.T) {
 	expected := token.Token{
 		Type:   token.Identifier,
 		Lexeme: "oke",
+		Span:   span(0, 3),
 	}
 	next := lexer.Next()
 	assert.Equal(t, expected, next)
@@ -269,6 +354,7 @@ func TestScanner_Next_Semi(t *testing.T) {
 	expected = token.Token{
 		Type:   token.Semi,
 		Lexeme: ";",
+		Span:   span(3, 1),
 	}
 
 	next = lexer.Next()
@@ -285,6 +371,7 @@ func TestScanner_Next_Dot(t *testing.T) {
 	expected := token.Token{
 		Type:   token.Identifier,
 		Lexeme: "oke",
+		Span:   span(0, 3),
 	}
 	next := lexer.Next()
 	assert.Equal(t, expected, next)
@@ -292,6 +379,7 @@ func TestScanner_Next_Dot(t *testing.T) {
 	expected = token.Token{
 		Type:   token.Dot,
 		Lexeme: ".",
+		Span:   span(3, 1),
 	}
 
 	next = lexer.Next()
@@ -321,3 +409,790 @@ func TestNew(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, scan.Stream, "unable to instantiate")
 }
+
+func TestScanner_Next_Position(t *testing.T) {
+	input := "begin\n  x := 1\nend."
+	expected := []token.Position{
+		{Offset: 0, Line: 1, Column: 1},
+		{Offset: 8, Line: 2, Column: 3},
+		{Offset: 10, Line: 2, Column: 5},
+		{Offset: 13, Line: 2, Column: 8},
+		{Offset: 15, Line: 3, Column: 1},
+		{Offset: 18, Line: 3, Column: 4},
+		{Offset: 19, Line: 3, Column: 5},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+	lexer.File = "main.pas"
+
+	for _, position := range expected {
+		position.File = "main.pas"
+		next := lexer.Next()
+		assert.Equal(t, position, next.Span.Start)
+	}
+
+	lexer, err = scanner.New("begin\nend")
+	assert.Nil(t, err)
+
+	next := lexer.Next()
+	assert.Equal(t, token.Position{Offset: 5, Line: 1, Column: 6}, next.Span.End)
+	next = lexer.Next()
+	assert.Equal(t, token.Position{Offset: 9, Line: 2, Column: 4}, next.Span.End)
+}
+
+func TestScanner_Next_Whitespace(t *testing.T) {
+	input := "begin\r\n\tx\f:=\v1\r\nend"
+	expected := []int{token.Begin, token.Identifier, token.Assign, token.Int, token.End, token.EOF}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, tokenType := range expected {
+		assert.Equal(t, tokenType, lexer.Next().Type, unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Illegal(t *testing.T) {
+	input := "x _ } ! # é ?"
+	expected := []token.Token{
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(0, 1),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "_",
+			Span:   span(2, 1),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "}",
+			Span:   span(4, 1),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "!",
+			Span:   span(6, 1),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "#",
+			Span:   span(8, 1),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "é",
+			Span:   span(10, 2),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "?",
+			Span:   span(13, 1),
+		},
+		{
+			Type:   token.EOF,
+			Lexeme: "",
+			Span:   span(14, 0),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+// TestScanner_Next_ArbitraryBytes feeds arbitrary bytes to the scanner, every call to Next
+// has to consume at least one byte until the end of the input stream is reached.
+func TestScanner_Next_ArbitraryBytes(t *testing.T) {
+	var inputs []string
+	for character := 0; character < 256; character++ {
+		inputs = append(inputs, string([]byte{byte(character)}), "1"+string([]byte{byte(character)})+"a")
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		input := make([]byte, random.Intn(64))
+		random.Read(input)
+		inputs = append(inputs, string(input))
+	}
+
+	for _, input := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		next := lexer.Next()
+		for calls := 0; next.Type != token.EOF; calls++ {
+			if calls > len(input) {
+				t.Fatalf("scanner doesn't reach the end of input %q", input)
+			}
+			next = lexer.Next()
+		}
+		assert.Equal(t, len(input), next.Span.End.Offset)
+	}
+}
+
+func TestScanner_Next_Comments(t *testing.T) {
+	input := "{ brace } x (* paren *) := // line\r\n1 (*)*) {*}"
+	expected := []token.Token{
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(10, 1),
+		},
+		{
+			Type:   token.Assign,
+			Lexeme: ":=",
+			Span:   span(24, 2),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+
+	next := lexer.Next()
+	assert.Equal(t, token.Int, next.Type)
+	assert.Equal(t, token.Position{Offset: 36, Line: 2, Column: 1}, next.Span.Start)
+	assert.Equal(t, token.EOF, lexer.Next().Type)
+
+	comments := []string{"{ brace }", "(* paren *)", "// line", "(*)*)", "{*}"}
+	assert.Len(t, lexer.Comments, len(comments))
+	for i, comment := range comments {
+		assert.Equal(t, token.Comment, lexer.Comments[i].Type)
+		assert.Equal(t, comment, lexer.Comments[i].Lexeme)
+	}
+	assert.Equal(t, span(0, 9), lexer.Comments[0].Span)
+}
+
+func TestScanner_Next_UnterminatedComment(t *testing.T) {
+	inputs := map[string]string{
+		"x { never closed":  "{ never closed",
+		"x (* never closed": "(* never closed",
+		"x (* closed }":     "(* closed }",
+		"x { nested (* *)":  "{ nested (* *)",
+	}
+
+	for input, lexeme := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		assert.Equal(t, token.Identifier, lexer.Next().Type)
+
+		expected := token.Token{
+			Type:   token.UnterminatedComment,
+			Lexeme: lexeme,
+			Span:   span(2, len(lexeme)),
+		}
+		assert.Equal(t, expected, lexer.Next())
+		assert.Equal(t, token.EOF, lexer.Next().Type)
+	}
+}
+
+func TestScanner_Next_Declarations(t *testing.T) {
+	input := "PROGRAM part10; VAR a, b2 : Integer; r : REAL;"
+	expected := []token.Token{
+		{
+			Type:   token.Program,
+			Lexeme: "program",
+			Span:   span(0, 7),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "part10",
+			Span:   span(8, 6),
+		},
+		{
+			Type:   token.Semi,
+			Lexeme: ";",
+			Span:   span(14, 1),
+		},
+		{
+			Type:   token.Var,
+			Lexeme: "var",
+			Span:   span(16, 3),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "a",
+			Span:   span(20, 1),
+		},
+		{
+			Type:   token.Comma,
+			Lexeme: ",",
+			Span:   span(21, 1),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "b2",
+			Span:   span(23, 2),
+		},
+		{
+			Type:   token.Colon,
+			Lexeme: ":",
+			Span:   span(26, 1),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "integer",
+			Span:   span(28, 7),
+		},
+		{
+			Type:   token.Semi,
+			Lexeme: ";",
+			Span:   span(35, 1),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "r",
+			Span:   span(37, 1),
+		},
+		{
+			Type:   token.Colon,
+			Lexeme: ":",
+			Span:   span(39, 1),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "real",
+			Span:   span(41, 4),
+		},
+		{
+			Type:   token.Semi,
+			Lexeme: ";",
+			Span:   span(45, 1),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Real(t *testing.T) {
+	inputs := map[string]token.Token{
+		"3.14":   {Type: token.Real, Lexeme: "3.14", Span: span(0, 4)},
+		"1.5E-3": {Type: token.Real, Lexeme: "1.5E-3", Span: span(0, 6)},
+		"2e+10":  {Type: token.Real, Lexeme: "2e+10", Span: span(0, 5)},
+		"7E2":    {Type: token.Real, Lexeme: "7E2", Span: span(0, 3)},
+		"42":     {Type: token.Int, Lexeme: "42", Span: span(0, 2)},
+		"1.":     {Type: token.Int, Lexeme: "1", Span: span(0, 1)},
+		"1..5":   {Type: token.Int, Lexeme: "1", Span: span(0, 1)},
+		"3e":     {Type: token.Int, Lexeme: "3", Span: span(0, 1)},
+		"3e+x":   {Type: token.Int, Lexeme: "3", Span: span(0, 1)},
+	}
+
+	for input, expected := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		assert.Equal(t, expected, lexer.Next(), input)
+	}
+}
+
+func TestScanner_Next_DivMod(t *testing.T) {
+	input := "7 DIV 2 mod x"
+	expected := []token.Token{
+		{
+			Type:   token.Int,
+			Lexeme: "7",
+			Span:   span(0, 1),
+		},
+		{
+			Type:   token.IntDiv,
+			Lexeme: "div",
+			Span:   span(2, 3),
+		},
+		{
+			Type:   token.Int,
+			Lexeme: "2",
+			Span:   span(6, 1),
+		},
+		{
+			Type:   token.Mod,
+			Lexeme: "mod",
+			Span:   span(8, 3),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(12, 1),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Boolean(t *testing.T) {
+	input := "< <= <> > >= = and Or NOT true False boolean"
+	expected := []token.Token{
+		{
+			Type:   token.Less,
+			Lexeme: "<",
+			Span:   span(0, 1),
+		},
+		{
+			Type:   token.LessEqual,
+			Lexeme: "<=",
+			Span:   span(2, 2),
+		},
+		{
+			Type:   token.NotEqual,
+			Lexeme: "<>",
+			Span:   span(5, 2),
+		},
+		{
+			Type:   token.Greater,
+			Lexeme: ">",
+			Span:   span(8, 1),
+		},
+		{
+			Type:   token.GreaterEqual,
+			Lexeme: ">=",
+			Span:   span(10, 2),
+		},
+		{
+			Type:   token.Equal,
+			Lexeme: "=",
+			Span:   span(13, 1),
+		},
+		{
+			Type:   token.And,
+			Lexeme: "and",
+			Span:   span(15, 3),
+		},
+		{
+			Type:   token.Or,
+			Lexeme: "or",
+			Span:   span(19, 2),
+		},
+		{
+			Type:   token.Not,
+			Lexeme: "not",
+			Span:   span(22, 3),
+		},
+		{
+			Type:   token.True,
+			Lexeme: "true",
+			Span:   span(26, 4),
+		},
+		{
+			Type:   token.False,
+			Lexeme: "false",
+			Span:   span(31, 5),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "boolean",
+			Span:   span(37, 7),
+		},
+		{
+			Type:   token.EOF,
+			Lexeme: "",
+			Span:   span(44, 0),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_If(t *testing.T) {
+	input := "IF x THEN else"
+	expected := []token.Token{
+		{
+			Type:   token.If,
+			Lexeme: "if",
+			Span:   span(0, 2),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(3, 1),
+		},
+		{
+			Type:   token.Then,
+			Lexeme: "then",
+			Span:   span(5, 4),
+		},
+		{
+			Type:   token.Else,
+			Lexeme: "else",
+			Span:   span(10, 4),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Loops(t *testing.T) {
+	input := "while do repeat until for to downto"
+	expected := []token.Token{
+		{
+			Type:   token.While,
+			Lexeme: "while",
+			Span:   span(0, 5),
+		},
+		{
+			Type:   token.Do,
+			Lexeme: "do",
+			Span:   span(6, 2),
+		},
+		{
+			Type:   token.Repeat,
+			Lexeme: "repeat",
+			Span:   span(9, 6),
+		},
+		{
+			Type:   token.Until,
+			Lexeme: "until",
+			Span:   span(16, 5),
+		},
+		{
+			Type:   token.For,
+			Lexeme: "for",
+			Span:   span(22, 3),
+		},
+		{
+			Type:   token.To,
+			Lexeme: "to",
+			Span:   span(26, 2),
+		},
+		{
+			Type:   token.Downto,
+			Lexeme: "downto",
+			Span:   span(29, 6),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Case(t *testing.T) {
+	input := "case x of 3..5: 1.5."
+	expected := []token.Token{
+		{
+			Type:   token.Case,
+			Lexeme: "case",
+			Span:   span(0, 4),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(5, 1),
+		},
+		{
+			Type:   token.Of,
+			Lexeme: "of",
+			Span:   span(7, 2),
+		},
+		{
+			Type:   token.Int,
+			Lexeme: "3",
+			Span:   span(10, 1),
+		},
+		{
+			Type:   token.Range,
+			Lexeme: "..",
+			Span:   span(11, 2),
+		},
+		{
+			Type:   token.Int,
+			Lexeme: "5",
+			Span:   span(13, 1),
+		},
+		{
+			Type:   token.Colon,
+			Lexeme: ":",
+			Span:   span(14, 1),
+		},
+		{
+			Type:   token.Real,
+			Lexeme: "1.5",
+			Span:   span(16, 3),
+		},
+		{
+			Type:   token.Dot,
+			Lexeme: ".",
+			Span:   span(19, 1),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Subroutines(t *testing.T) {
+	input := "Procedure FUNCTION var const"
+	expected := []token.Token{
+		{
+			Type:   token.Procedure,
+			Lexeme: "procedure",
+			Span:   span(0, 9),
+		},
+		{
+			Type:   token.Function,
+			Lexeme: "function",
+			Span:   span(10, 8),
+		},
+		{
+			Type:   token.Var,
+			Lexeme: "var",
+			Span:   span(19, 3),
+		},
+		{
+			Type:   token.Const,
+			Lexeme: "const",
+			Span:   span(23, 5),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Strings(t *testing.T) {
+	input := "'it''s' 'a'#10 '' #65 Char STRING #300 'open"
+	expected := []token.Token{
+		{
+			Type:   token.String,
+			Lexeme: "'it''s'",
+			Span:   span(0, 7),
+		},
+		{
+			Type:   token.String,
+			Lexeme: "'a'#10",
+			Span:   span(8, 6),
+		},
+		{
+			Type:   token.String,
+			Lexeme: "''",
+			Span:   span(15, 2),
+		},
+		{
+			Type:   token.String,
+			Lexeme: "#65",
+			Span:   span(18, 3),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "char",
+			Span:   span(22, 4),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "string",
+			Span:   span(27, 6),
+		},
+		{
+			Type:   token.Illegal,
+			Lexeme: "#300",
+			Span:   span(34, 4),
+		},
+		{
+			Type:   token.UnterminatedString,
+			Lexeme: "'open",
+			Span:   span(39, 5),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Arrays(t *testing.T) {
+	input := "ARRAY[1..10] OF x[i]"
+	expected := []token.Token{
+		{
+			Type:   token.Array,
+			Lexeme: "array",
+			Span:   span(0, 5),
+		},
+		{
+			Type:   token.Lbracket,
+			Lexeme: "[",
+			Span:   span(5, 1),
+		},
+		{
+			Type:   token.Int,
+			Lexeme: "1",
+			Span:   span(6, 1),
+		},
+		{
+			Type:   token.Range,
+			Lexeme: "..",
+			Span:   span(7, 2),
+		},
+		{
+			Type:   token.Int,
+			Lexeme: "10",
+			Span:   span(9, 2),
+		},
+		{
+			Type:   token.Rbracket,
+			Lexeme: "]",
+			Span:   span(11, 1),
+		},
+		{
+			Type:   token.Of,
+			Lexeme: "of",
+			Span:   span(13, 2),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(16, 1),
+		},
+		{
+			Type:   token.Lbracket,
+			Lexeme: "[",
+			Span:   span(17, 1),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "i",
+			Span:   span(18, 1),
+		},
+		{
+			Type:   token.Rbracket,
+			Lexeme: "]",
+			Span:   span(19, 1),
+		},
+		{
+			Type:   token.EOF,
+			Lexeme: "",
+			Span:   span(20, 0),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestScanner_Next_Records(t *testing.T) {
+	input := "TYPE p = RECORD END; WITH p.x"
+	expected := []token.Token{
+		{
+			Type:   token.Type,
+			Lexeme: "type",
+			Span:   span(0, 4),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "p",
+			Span:   span(5, 1),
+		},
+		{
+			Type:   token.Equal,
+			Lexeme: "=",
+			Span:   span(7, 1),
+		},
+		{
+			Type:   token.Record,
+			Lexeme: "record",
+			Span:   span(9, 6),
+		},
+		{
+			Type:   token.End,
+			Lexeme: "end",
+			Span:   span(16, 3),
+		},
+		{
+			Type:   token.Semi,
+			Lexeme: ";",
+			Span:   span(19, 1),
+		},
+		{
+			Type:   token.With,
+			Lexeme: "with",
+			Span:   span(21, 4),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "p",
+			Span:   span(26, 1),
+		},
+		{
+			Type:   token.Dot,
+			Lexeme: ".",
+			Span:   span(27, 1),
+		},
+		{
+			Type:   token.Identifier,
+			Lexeme: "x",
+			Span:   span(28, 1),
+		},
+		{
+			Type:   token.EOF,
+			Lexeme: "",
+			Span:   span(29, 0),
+		},
+	}
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	for _, next := range expected {
+		assert.Equal(t, next, lexer.Next(), unexpectedTokenError)
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	inputs := map[string]string{
+		"'it''s'":   "it's",
+		"'a'#10":    "a\n",
+		"''":        "",
+		"''''":      "'",
+		"#72#105":   "Hi",
+		"'a'#33'b'": "a!b",
+	}
+
+	for input, expected := range inputs {
+		assert.Equal(t, expected, scanner.Unquote(input), input)
+	}
+}
@@ -1,5 +1,7 @@
 package token
 
+import "fmt"
+
 const (
 	Int = iota
 	Add
@@ -15,10 +17,158 @@ const (
 	Dot
 	Identifier
 	EOF
+	// Illegal is a character that isn't part of the language, the lexeme contains the character.
+	Illegal
+	// Comment is never returned by the scanner, comments are collected as trivia instead.
+	Comment
+	UnterminatedComment
+	Program
+	Var
+	Colon
+	Comma
+	// Real is a real number literal, for instance 3.14 or 1.5E-3.
+	Real
+	// IntDiv is the integer division keyword DIV, Div is the real division operator '/'.
+	IntDiv
+	Mod
+	Equal
+	NotEqual
+	Less
+	LessEqual
+	Greater
+	GreaterEqual
+	And
+	Or
+	Not
+	True
+	False
+	If
+	Then
+	Else
+	While
+	Do
+	Repeat
+	Until
+	For
+	To
+	Downto
+	Case
+	Of
+	// Range is the '..' separating the bounds of a range, for instance the CASE label 3..5.
+	Range
+	Procedure
+	Function
+	Const
+	// String is a character string, for instance 'it''s' or 'a'#10. The lexeme contains the
+	// string as it's written in the source code, including the quotes.
+	String
+	UnterminatedString
+	Lbracket
+	Rbracket
+	Array
+	Type
+	Record
+	With
 )
 
-// Token contains the token type and the lexeme
+var names = map[int]string{
+	Int:                 "integer",
+	Add:                 "'+'",
+	Sub:                 "'-'",
+	Div:                 "'/'",
+	Mul:                 "'*'",
+	Lparen:              "'('",
+	Rparen:              "')'",
+	Begin:               "BEGIN",
+	End:                 "END",
+	Assign:              "':='",
+	Semi:                "';'",
+	Dot:                 "'.'",
+	Identifier:          "identifier",
+	EOF:                 "end of file",
+	Illegal:             "illegal character",
+	Comment:             "comment",
+	UnterminatedComment: "unterminated comment",
+	Program:             "PROGRAM",
+	Var:                 "VAR",
+	Colon:               "':'",
+	Comma:               "','",
+	Real:                "real number",
+	IntDiv:              "DIV",
+	Mod:                 "MOD",
+	Equal:               "'='",
+	NotEqual:            "'<>'",
+	Less:                "'<'",
+	LessEqual:           "'<='",
+	Greater:             "'>'",
+	GreaterEqual:        "'>='",
+	And:                 "AND",
+	Or:                  "OR",
+	Not:                 "NOT",
+	True:                "TRUE",
+	False:               "FALSE",
+	If:                  "IF",
+	Then:                "THEN",
+	Else:                "ELSE",
+	While:               "WHILE",
+	Do:                  "DO",
+	Repeat:              "REPEAT",
+	Until:               "UNTIL",
+	For:                 "FOR",
+	To:                  "TO",
+	Downto:              "DOWNTO",
+	Case:                "CASE",
+	Of:                  "OF",
+	Range:               "'..'",
+	Procedure:           "PROCEDURE",
+	Function:            "FUNCTION",
+	Const:               "CONST",
+	String:              "string",
+	UnterminatedString:  "unterminated string",
+	Lbracket:            "'['",
+	Rbracket:            "']'",
+	Array:               "ARRAY",
+	Type:                "TYPE",
+	Record:              "RECORD",
+	With:                "WITH",
+}
+
+// Name returns a human readable description of the token type.
+func Name(tokenType int) string {
+	name, ok := names[tokenType]
+	if !ok {
+		return "unknown token"
+	}
+	return name
+}
+
+// Token contains the token type, the lexeme and the span of the lexeme in the input stream
 type Token struct {
 	Type   int
 	Lexeme string
+	Span   Span
+}
+
+// Position describes a single location in the input stream. Offset is the zero-based
+// byte offset, Line and Column are one-based.
+type Position struct {
+	File   string
+	Offset int
+	Line   int
+	Column int
+}
+
+// String formats the position as file:line:column, the file is omitted when it is unknown.
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Span describes the part of the input stream between Start and End. End points
+// just past the last character of the span.
+type Span struct {
+	Start Position
+	End   Position
 }
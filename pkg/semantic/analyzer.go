@@ -0,0 +1,560 @@
+// Package semantic checks the abstract syntax tree for errors that the grammar can't express.
+//
+// The analyzer builds a symbol table for the program and for every procedure and function,
+// and reports identifiers that aren't declared, identifiers that are declared more than once
+// in the same scope and variables that are used before they are assigned a value. Inside a WITH
+// statement the fields of its records are resolved before the identifiers of the scopes. The
+// analysis doesn't execute the program, so it can run without the interpreter.
+//
+// The checker uses the symbol tables to determine the static type of every expression and
+// reports the type errors, so they are found before the program runs.
+package semantic
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+)
+
+// Analyzer walks the abstract syntax tree of a program and collects the semantic errors.
+type Analyzer struct {
+	scope  *Scope
+	errors ErrorList
+
+	// assigned contains the variables of the current scope that are assigned a value on every
+	// path through the statements that were analyzed so far.
+	assigned map[*Symbol]bool
+	// specs maps the type specifications that were analyzed to the type specification they stand
+	// for. A name declared in a TYPE section stands for the type of its declaration, a name that
+	// isn't declared as a type stands for nil. Names are resolved in the scope the type
+	// specification is declared in.
+	specs map[*ast.TypeSpec]*ast.TypeSpec
+	// fields contains the fields of the records of the enclosing WITH statements, the innermost
+	// last.
+	fields []map[string]*Symbol
+	// assigns maps every procedure and function to the variables of the enclosing scopes it
+	// assigns itself, calls maps it to the procedures and functions it calls. Together they
+	// determine the variables that a call can assign.
+	assigns map[*Symbol]map[*Symbol]bool
+	calls   map[*Symbol][]*Symbol
+}
+
+// New creates an analyzer.
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+// Analyze checks the program and returns the scope of the program, which contains the scopes
+// of the procedures and functions. All semantic errors found are returned as an ErrorList.
+func (a *Analyzer) Analyze(program *ast.Program) (*Scope, error) {
+	a.errors = nil
+	a.scope = NewScope(program.Name, nil, standard())
+	a.assigned = make(map[*Symbol]bool)
+	a.specs = make(map[*ast.TypeSpec]*ast.TypeSpec)
+	a.fields = nil
+	a.assigns = make(map[*Symbol]map[*Symbol]bool)
+	a.calls = make(map[*Symbol][]*Symbol)
+
+	global := a.scope
+	a.block(program.Block)
+
+	if len(a.errors) > 0 {
+		return global, a.errors
+	}
+	return global, nil
+}
+
+// block declares the declarations of the block in the current scope before analyzing the
+// statements.
+func (a *Analyzer) block(block *ast.Block) {
+	if block == nil {
+		return
+	}
+
+	for _, declaration := range block.Declarations {
+		switch d := declaration.(type) {
+		case *ast.TypeDecl:
+			a.declare(d, &Symbol{Name: d.Name, Kind: Definition, Type: a.typeName(d.Type), Declaration: d})
+		case *ast.VarDecl:
+			a.declare(d, &Symbol{Name: d.Variable.Name, Kind: Variable, Type: a.typeName(d.Type), Declaration: d})
+		case *ast.ProcedureDecl:
+			symbol := &Symbol{Name: d.Name, Kind: Procedure, Declaration: d}
+			a.declare(d, symbol)
+			a.subroutine(symbol, d.Parameters, d.Block)
+		case *ast.FunctionDecl:
+			symbol := &Symbol{Name: d.Name, Kind: Function, Type: a.typeName(d.ReturnType), Declaration: d}
+			a.declare(d, symbol)
+			a.subroutine(symbol, d.Parameters, d.Block)
+		}
+	}
+
+	if block.Compound != nil {
+		a.statement(block.Compound)
+	}
+}
+
+// declare inserts the symbol in the current scope, it is an error to declare an identifier
+// twice in the same scope.
+func (a *Analyzer) declare(node ast.Node, symbol *Symbol) {
+	if !a.scope.Insert(symbol) {
+		a.fail(node, "%s %s is declared more than once", symbol.Kind, symbol.Name)
+	}
+}
+
+// subroutine analyzes the block of a procedure or function in a new scope that contains its
+// parameters. The symbol is declared before, so the procedure or function can call itself.
+func (a *Analyzer) subroutine(symbol *Symbol, parameters []*ast.Param, block *ast.Block) {
+	scope, assigned := a.scope, a.assigned
+	a.scope = NewScope(symbol.Name, symbol, scope)
+	a.assigned = make(map[*Symbol]bool)
+
+	for _, parameter := range parameters {
+		variable := &Symbol{
+			Name:        parameter.Variable.Name,
+			Kind:        Parameter,
+			Type:        a.typeName(parameter.Type),
+			Declaration: parameter,
+		}
+		symbol.Parameters = append(symbol.Parameters, variable)
+		a.declare(parameter, variable)
+	}
+
+	a.block(block)
+	a.scope, a.assigned = scope, assigned
+}
+
+// typeSpec resolves the names in the type specification and returns the type specification it
+// stands for, nil is returned when a name isn't declared as a type. The fields of a RECORD type
+// have to have different names.
+func (a *Analyzer) typeSpec(spec *ast.TypeSpec) *ast.TypeSpec {
+	if spec == nil {
+		return nil
+	}
+	if resolved, ok := a.specs[spec]; ok {
+		return resolved
+	}
+
+	resolved := spec
+	switch {
+	case spec.Token.Type == token.Identifier:
+		symbol := a.scope.Lookup(spec.Name)
+		if symbol == nil {
+			a.fail(spec, "type %s is not declared", spec.Name)
+			resolved = nil
+		} else if symbol.Kind != Definition {
+			a.fail(spec, "%s %s is not a type", symbol.Kind, spec.Name)
+			resolved = nil
+		} else {
+			resolved = a.typeSpec(symbol.Declaration.(*ast.TypeDecl).Type)
+		}
+	case spec.Element != nil:
+		a.typeSpec(spec.Element)
+	case spec.Fields != nil:
+		names := make(map[string]bool)
+		for _, field := range spec.Fields {
+			if names[field.Variable.Name] {
+				a.fail(field, "field %s is declared more than once", field.Variable.Name)
+			}
+			names[field.Variable.Name] = true
+			a.typeSpec(field.Type)
+		}
+	}
+
+	a.specs[spec] = resolved
+	return resolved
+}
+
+// typeName resolves the names in the type specification and returns the name of the type it
+// stands for, which is empty when a name isn't declared as a type.
+func (a *Analyzer) typeName(spec *ast.TypeSpec) string {
+	resolved := a.typeSpec(spec)
+	if resolved == nil {
+		return ""
+	}
+	return resolved.Name
+}
+
+// statement analyzes the statement and updates the variables that are assigned.
+func (a *Analyzer) statement(statement ast.Statement) {
+	switch s := statement.(type) {
+	case *ast.Compound:
+		a.statements(s.Statements)
+	case *ast.Assign:
+		a.expression(s.Right)
+		if variable, ok := s.Left.(*ast.Variable); ok {
+			a.assign(variable)
+		}
+	case *ast.ProcedureCall:
+		a.call(s, s.Name, s.Arguments, Procedure)
+	case *ast.If:
+		a.expression(s.Condition)
+		before := a.assigned
+		then := a.branch(before, s.Then)
+		otherwise := a.branch(before, s.Else)
+		a.assigned = intersect(then, otherwise)
+	case *ast.While:
+		a.expression(s.Condition)
+		// The body might not be executed at all.
+		before := a.assigned
+		a.branch(before, s.Body)
+		a.assigned = before
+	case *ast.Repeat:
+		// The statements are executed at least once.
+		a.statements(s.Statements)
+		a.expression(s.Condition)
+	case *ast.For:
+		a.forStatement(s)
+	case *ast.Case:
+		a.caseStatement(s)
+	case *ast.With:
+		a.with(s)
+	}
+}
+
+// statements analyzes the statements in order.
+func (a *Analyzer) statements(statements []ast.Statement) {
+	for _, statement := range statements {
+		a.statement(statement)
+	}
+}
+
+// branch analyzes a statement that is executed conditionally, starting from the variables that
+// are assigned before. The variables that are assigned afterwards are returned, a missing
+// statement doesn't assign any variables.
+func (a *Analyzer) branch(before map[*Symbol]bool, statement ast.Statement) map[*Symbol]bool {
+	a.assigned = make(map[*Symbol]bool)
+	for symbol := range before {
+		a.assigned[symbol] = true
+	}
+
+	if statement != nil {
+		a.statement(statement)
+	}
+	return a.assigned
+}
+
+// forStatement analyzes a FOR statement. The control variable is assigned inside the body, but
+// is undefined once the loop has finished.
+func (a *Analyzer) forStatement(statement *ast.For) {
+	a.expression(statement.Start)
+	a.expression(statement.End)
+
+	symbol := a.resolve(statement.Variable)
+	before := a.assigned
+	a.branch(before, nil)
+	if symbol != nil {
+		a.assigned[symbol] = true
+		a.modify(symbol)
+	}
+	a.statement(statement.Body)
+
+	a.assigned = before
+	if symbol != nil {
+		delete(a.assigned, symbol)
+	}
+}
+
+// caseStatement analyzes a CASE statement. A variable is assigned afterwards when every branch
+// assigns it; without an ELSE part no branch is executed when no label matches, but then the
+// execution fails.
+func (a *Analyzer) caseStatement(statement *ast.Case) {
+	a.expression(statement.Expression)
+
+	before := a.assigned
+	var after map[*Symbol]bool
+	for _, branch := range statement.Branches {
+		for _, label := range branch.Labels {
+			a.expression(label.Low)
+			if label.High != nil {
+				a.expression(label.High)
+			}
+		}
+
+		assigned := a.branch(before, branch.Statement)
+		after = intersect(after, assigned)
+	}
+
+	if statement.Else != nil {
+		assigned := a.branch(before, &ast.Compound{Statements: statement.Else})
+		after = intersect(after, assigned)
+	}
+
+	a.assigned = after
+	if after == nil {
+		a.assigned = before
+	}
+}
+
+// with analyzes the body of a WITH statement, in which the fields of the RECORD variables can be
+// accessed by their names. A variable that isn't a RECORD has no fields, the checker reports it.
+func (a *Analyzer) with(statement *ast.With) {
+	count := len(a.fields)
+	for _, variable := range statement.Variables {
+		a.expression(variable)
+
+		fields := make(map[string]*Symbol)
+		if record := a.record(variable); record != nil {
+			for _, field := range record.Fields {
+				name := field.Variable.Name
+				fields[name] = &Symbol{Name: name, Kind: Field, Type: a.typeName(field.Type), Declaration: field}
+			}
+		}
+		a.fields = append(a.fields, fields)
+	}
+
+	a.statement(statement.Body)
+	a.fields = a.fields[:count]
+}
+
+// record returns the RECORD type specification of the variable, taking its selectors into
+// account. Nil is returned when the variable isn't a RECORD.
+func (a *Analyzer) record(variable *ast.Variable) *ast.TypeSpec {
+	symbol := a.lookup(variable.Name)
+	if symbol == nil {
+		return nil
+	}
+
+	var spec *ast.TypeSpec
+	switch declaration := symbol.Declaration.(type) {
+	case *ast.VarDecl:
+		spec = a.specs[declaration.Type]
+	case *ast.Param:
+		spec = a.specs[declaration.Type]
+	}
+
+	// An ARRAY type with more than one range of indexes takes an index for every range before
+	// its elements are selected.
+	ranges := 0
+	for _, selector := range variable.Selectors {
+		if spec == nil {
+			return nil
+		}
+
+		field, ok := selector.(*ast.Field)
+		if ok {
+			next := spec
+			spec = nil
+			for _, declaration := range next.Fields {
+				if declaration.Variable.Name == field.Name {
+					spec = a.specs[declaration.Type]
+				}
+			}
+			continue
+		}
+
+		if spec.Element == nil {
+			return nil
+		}
+		ranges++
+		if ranges == len(spec.Ranges) {
+			spec, ranges = a.specs[spec.Element], 0
+		}
+	}
+
+	if spec == nil || spec.Fields == nil || ranges > 0 {
+		return nil
+	}
+	return spec
+}
+
+// expression analyzes the variables and function calls of the expression.
+func (a *Analyzer) expression(expression ast.Expr) {
+	switch e := expression.(type) {
+	case *ast.BinOp:
+		a.expression(e.Left)
+		a.expression(e.Right)
+	case *ast.UnaryOp:
+		a.expression(e.Expression)
+	case *ast.FunctionCall:
+		a.call(e, e.Name, e.Arguments, Function)
+	case *ast.WriteParam:
+		a.expression(e.Expression)
+		a.expression(e.Width)
+		a.expression(e.Precision)
+	case *ast.Variable:
+		a.selectors(e)
+
+		symbol := a.resolve(e)
+		if symbol == nil || !a.tracked(symbol) || a.assigned[symbol] {
+			return
+		}
+
+		a.fail(e, "variable %s is used before it is assigned a value", e.Name)
+		// The error is only reported for the first use of the variable.
+		a.assigned[symbol] = true
+	}
+}
+
+// call analyzes the arguments of a call. A variable passed to a VAR parameter or to Read doesn't
+// need a value, it is assigned by the procedure or function. Afterwards the variables of the
+// current scope that the procedure or function assigns are assigned as well.
+func (a *Analyzer) call(node ast.Node, name string, arguments []ast.Expr, kind Kind) {
+	symbol := a.scope.Lookup(name)
+	if symbol == nil || (symbol.Kind != Procedure && symbol.Kind != Function) {
+		a.fail(node, "%s %s is not declared", kind, name)
+	}
+
+	for index, argument := range arguments {
+		variable, ok := argument.(*ast.Variable)
+		if ok && isRead(symbol) {
+			a.assign(variable)
+			continue
+		}
+		if ok && symbol != nil && index < len(symbol.Parameters) && isReference(symbol.Parameters[index]) {
+			a.assign(variable)
+			continue
+		}
+		a.expression(argument)
+	}
+
+	if symbol == nil || symbol.Builtin || (symbol.Kind != Procedure && symbol.Kind != Function) {
+		return
+	}
+	if owner := a.scope.Owner; owner != nil {
+		a.calls[owner] = append(a.calls[owner], symbol)
+	}
+	for variable := range a.assignedBy(symbol, make(map[*Symbol]bool)) {
+		if a.tracked(variable) {
+			a.assigned[variable] = true
+		}
+	}
+}
+
+// assignedBy returns the variables of the enclosing scopes of the procedure or function that
+// a call of it can assign, either itself or through the procedures and functions it calls. The
+// procedures and functions that were visited already are skipped, so recursion ends.
+func (a *Analyzer) assignedBy(callee *Symbol, visited map[*Symbol]bool) map[*Symbol]bool {
+	assigned := make(map[*Symbol]bool)
+	if visited[callee] {
+		return assigned
+	}
+	visited[callee] = true
+
+	for variable := range a.assigns[callee] {
+		if !variable.Scope.Encloses(callee) {
+			assigned[variable] = true
+		}
+	}
+	for _, called := range a.calls[callee] {
+		for variable := range a.assignedBy(called, visited) {
+			if !variable.Scope.Encloses(callee) {
+				assigned[variable] = true
+			}
+		}
+	}
+	return assigned
+}
+
+// modify records a variable of an enclosing scope that is assigned inside a procedure or
+// function, a call of the procedure or function assigns the variable as well.
+func (a *Analyzer) modify(symbol *Symbol) {
+	owner := a.scope.Owner
+	if owner == nil || symbol.Kind != Variable || symbol.Scope == a.scope {
+		return
+	}
+
+	if a.assigns[owner] == nil {
+		a.assigns[owner] = make(map[*Symbol]bool)
+	}
+	a.assigns[owner][symbol] = true
+}
+
+// assign marks the variable as assigned, the indexes in the selectors of the variable are
+// analyzed as expressions.
+func (a *Analyzer) assign(variable *ast.Variable) {
+	a.selectors(variable)
+
+	symbol := a.resolve(variable)
+	if symbol == nil {
+		return
+	}
+
+	switch symbol.Kind {
+	case Procedure, Definition:
+		a.fail(variable, "unable to assign to %s, it isn't a variable", variable.Name)
+	case Function:
+		// The result of a function is assigned to its name inside the function.
+		if !a.scope.Encloses(symbol) {
+			a.fail(variable, "unable to assign to %s, it isn't a variable", variable.Name)
+		}
+	default:
+		a.assigned[symbol] = true
+		a.modify(symbol)
+	}
+}
+
+// selectors analyzes the indexes in the selectors of the variable, the fields are resolved by
+// the checker.
+func (a *Analyzer) selectors(variable *ast.Variable) {
+	for _, selector := range variable.Selectors {
+		if _, ok := selector.(*ast.Field); !ok {
+			a.expression(selector)
+		}
+	}
+}
+
+// resolve returns the symbol the variable refers to, an identifier that isn't declared is
+// reported and results in nil.
+func (a *Analyzer) resolve(variable *ast.Variable) *Symbol {
+	symbol := a.lookup(variable.Name)
+	if symbol == nil {
+		a.fail(variable, "identifier %s is not declared", variable.Name)
+	}
+	return symbol
+}
+
+// lookup returns the field of the innermost WITH statement with the name, or otherwise the
+// symbol with the name from the nearest scope that declares it. Nil is returned when the
+// identifier isn't declared.
+func (a *Analyzer) lookup(name string) *Symbol {
+	for index := len(a.fields) - 1; index >= 0; index-- {
+		if field, ok := a.fields[index][name]; ok {
+			return field
+		}
+	}
+	return a.scope.Lookup(name)
+}
+
+// tracked reports whether the analyzer tracks the assignments of the symbol. Only the variables
+// of the current scope are tracked, a variable of an enclosing scope may have been assigned
+// before the procedure or function was called. The elements of an ARRAY variable and the fields
+// of a RECORD variable are assigned one by one, the interpreter reports the use of an element
+// or a field that wasn't assigned instead.
+func (a *Analyzer) tracked(symbol *Symbol) bool {
+	return symbol.Kind == Variable && symbol.Scope == a.scope && typeNamed(symbol.Type) != nil
+}
+
+// fail adds the error to the semantic errors.
+func (a *Analyzer) fail(node ast.Node, format string, args ...interface{}) {
+	a.errors = append(a.errors, newSemanticError(node, format, args...))
+}
+
+// isReference reports whether the parameter is a VAR parameter.
+func isReference(parameter *Symbol) bool {
+	declaration, ok := parameter.Declaration.(*ast.Param)
+	return ok && declaration.Reference
+}
+
+// isConstant reports whether the symbol is a CONST parameter.
+func isConstant(symbol *Symbol) bool {
+	declaration, ok := symbol.Declaration.(*ast.Param)
+	return ok && declaration.Constant
+}
+
+// intersect returns the variables that are assigned in both sets, a nil set is treated as the
+// set of all variables.
+func intersect(first map[*Symbol]bool, second map[*Symbol]bool) map[*Symbol]bool {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+
+	result := make(map[*Symbol]bool)
+	for symbol := range first {
+		if second[symbol] {
+			result[symbol] = true
+		}
+	}
+	return result
+}
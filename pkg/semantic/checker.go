@@ -0,0 +1,942 @@
+package semantic
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"github.com/njirem95/simple-pascal/pkg/visitor"
+	"strconv"
+	"strings"
+)
+
+// Checker determines the static type of every expression of a program and reports the type
+// errors, for instance the assignment of a BOOLEAN value to an INTEGER variable. The checker
+// resolves identifiers in the scopes built by the Analyzer. The checker is a visitor that
+// overrides the statements and expressions it checks, the Walker visits the other nodes.
+type Checker struct {
+	ast.Walker
+
+	// Types maps every expression that was checked to its static type. Expressions that contain
+	// type errors have no type.
+	Types map[ast.Expr]*Type
+
+	scope *Scope
+	// specs maps the type specifications that were checked to the type they describe, so the
+	// errors of a type specification are only reported once.
+	specs map[*ast.TypeSpec]*Type
+	// with contains the RECORD variables of the enclosing WITH statements, the innermost last.
+	with []*ast.Variable
+	// controls contains the control variables of the enclosing FOR statements.
+	controls []string
+	errors   ErrorList
+}
+
+// NewChecker creates a checker that resolves identifiers in the scope of the program.
+func NewChecker(scope *Scope) *Checker {
+	checker := &Checker{
+		Types: make(map[ast.Expr]*Type),
+		scope: scope,
+		specs: make(map[*ast.TypeSpec]*Type),
+	}
+	checker.Visitor = checker
+	return checker
+}
+
+// Check annotates the expressions of the program with their type. All type errors found are
+// returned as an ErrorList.
+func (c *Checker) Check(program *ast.Program) error {
+	c.errors = nil
+	c.with = nil
+	c.controls = nil
+	c.Walk(program)
+
+	if len(c.errors) > 0 {
+		return c.errors
+	}
+	return nil
+}
+
+// TypeOf returns the static type of the expression, nil is returned when the expression
+// contains type errors.
+func (c *Checker) TypeOf(expression ast.Expr) *Type {
+	return c.Types[expression]
+}
+
+// VisitProcedureDecl checks the block of the procedure in its own scope.
+func (c *Checker) VisitProcedureDecl(node *ast.ProcedureDecl) (interface{}, error) {
+	c.subroutine(node, node.Parameters, node.Block)
+	return nil, nil
+}
+
+// VisitFunctionDecl checks the block of the function in its own scope. The result of a function
+// can't be an ARRAY or a RECORD, since its elements and fields can't be assigned one by one.
+func (c *Checker) VisitFunctionDecl(node *ast.FunctionDecl) (interface{}, error) {
+	if t := c.typeOf(node.ReturnType); !isSimple(t) {
+		c.fail(node.ReturnType, "expected the result of function %s to be of a simple type, got %s", node.Name, t)
+	}
+	c.subroutine(node, node.Parameters, node.Block)
+	return nil, nil
+}
+
+// subroutine checks the types of the parameters and the block of the procedure or function in
+// its own scope.
+func (c *Checker) subroutine(declaration ast.Declaration, parameters []*ast.Param, block *ast.Block) {
+	for _, parameter := range parameters {
+		c.typeOf(parameter.Type)
+	}
+
+	for _, child := range c.scope.Children {
+		if child.Owner != nil && child.Owner.Declaration == declaration {
+			scope := c.scope
+			c.scope = child
+			c.Walk(block)
+			c.scope = scope
+			return
+		}
+	}
+}
+
+// VisitAssign checks whether the value can be assigned to the variable.
+func (c *Checker) VisitAssign(node *ast.Assign) (interface{}, error) {
+	value := c.expression(node.Right)
+
+	variable, ok := node.Left.(*ast.Variable)
+	if !ok {
+		return nil, nil
+	}
+	c.control(node, variable)
+	c.writable(variable)
+
+	if t, record := c.field(variable.Name); record != nil {
+		target := c.element(variable, t)
+		if target != nil && value != nil && !assignable(target, value) {
+			c.fail(node, "unable to assign a value of type %s to %s field %s", value, target, variable.Name)
+		}
+		return nil, nil
+	}
+
+	symbol := c.scope.Lookup(variable.Name)
+	if symbol == nil || symbol.Kind == Procedure {
+		c.element(variable, nil)
+		return nil, nil
+	}
+
+	target := c.element(variable, c.declared(symbol))
+	if target == nil || value == nil {
+		return nil, nil
+	}
+	if !assignable(target, value) {
+		c.fail(node, "unable to assign a value of type %s to %s variable %s", value, target, variable.Name)
+	}
+	return nil, nil
+}
+
+func (c *Checker) VisitProcedureCall(node *ast.ProcedureCall) (interface{}, error) {
+	c.call(node, node.Name, node.Arguments)
+	return nil, nil
+}
+
+func (c *Checker) VisitIf(node *ast.If) (interface{}, error) {
+	c.condition(node.Condition, "IF")
+	c.Walk(node.Then)
+	c.Walk(node.Else)
+	return nil, nil
+}
+
+func (c *Checker) VisitWhile(node *ast.While) (interface{}, error) {
+	c.condition(node.Condition, "WHILE")
+	c.Walk(node.Body)
+	return nil, nil
+}
+
+func (c *Checker) VisitRepeat(node *ast.Repeat) (interface{}, error) {
+	for _, statement := range node.Statements {
+		c.Walk(statement)
+	}
+	c.condition(node.Condition, "UNTIL")
+	return nil, nil
+}
+
+// condition checks whether the condition of the statement is a BOOLEAN.
+func (c *Checker) condition(condition ast.Expr, statement string) {
+	t := c.expression(condition)
+	if t != nil && t != Boolean {
+		c.fail(condition, "expected the condition of %s to be a BOOLEAN, got %s", statement, t)
+	}
+}
+
+// VisitFor checks whether the control variable and the bounds are INTEGER. The control variable
+// can't be a CONST parameter and can't be assigned inside the body.
+func (c *Checker) VisitFor(node *ast.For) (interface{}, error) {
+	symbol := c.scope.Lookup(node.Variable.Name)
+	if _, record := c.field(node.Variable.Name); record != nil {
+		c.fail(node.Variable, "unable to use field %s as control variable", node.Variable.Name)
+	} else if symbol != nil && symbol.Kind != Procedure && symbol.Kind != Function {
+		if t := c.declared(symbol); t != nil && t != Integer {
+			c.fail(node.Variable, "expected control variable %s to be an INTEGER, got %s", symbol.Name, t)
+		}
+		if isConstant(symbol) {
+			c.fail(node.Variable, "unable to use CONST parameter %s as control variable", symbol.Name)
+		}
+	}
+	c.control(node, node.Variable)
+
+	for _, bound := range []ast.Expr{node.Start, node.End} {
+		t := c.expression(bound)
+		if t != nil && t != Integer {
+			c.fail(bound, "expected the bounds of FOR to be INTEGER, got %s", t)
+		}
+	}
+
+	c.controls = append(c.controls, node.Variable.Name)
+	c.Walk(node.Body)
+	c.controls = c.controls[:len(c.controls)-1]
+	return nil, nil
+}
+
+// control reports the statement when it assigns the control variable of an enclosing FOR
+// statement. A field of a WITH statement hides the control variable with the same name.
+func (c *Checker) control(statement ast.Node, variable *ast.Variable) {
+	if _, record := c.field(variable.Name); record != nil || len(variable.Selectors) > 0 {
+		return
+	}
+	for _, name := range c.controls {
+		if name == variable.Name {
+			c.fail(statement, "control variable %s can't be assigned inside the FOR statement", name)
+			return
+		}
+	}
+}
+
+// writable reports the variable when it is a CONST parameter or an element or field of one,
+// which can't be assigned.
+func (c *Checker) writable(variable *ast.Variable) {
+	if name := c.readOnly(variable); name == variable.Name {
+		c.fail(variable, "unable to assign to CONST parameter %s", name)
+	} else if name != "" {
+		c.fail(variable, "unable to assign to field %s of CONST parameter %s", variable.Name, name)
+	}
+}
+
+// readOnly returns the name of the CONST parameter the variable is or selects an element or
+// field of, inside a WITH statement a field can be a field of a CONST parameter as well. The name
+// is empty when the variable can be assigned.
+func (c *Checker) readOnly(variable *ast.Variable) string {
+	if _, record := c.field(variable.Name); record != nil {
+		return c.readOnly(record)
+	}
+	if symbol := c.scope.Lookup(variable.Name); symbol != nil && isConstant(symbol) {
+		return symbol.Name
+	}
+	return ""
+}
+
+// caseRange is a label of a CASE statement, it matches the ordinal numbers from first to last.
+type caseRange struct {
+	label *ast.CaseLabel
+	t     *Type
+	first int
+	last  int
+}
+
+// overlaps reports whether the ranges have an ordinal number in common.
+func (r caseRange) overlaps(other caseRange) bool {
+	return r.first <= other.last && other.first <= r.last
+}
+
+// String returns the label as it's written in the source code, for instance 3..5.
+func (r caseRange) String() string {
+	if r.label.High == nil {
+		return constant(r.t, r.first)
+	}
+	return constant(r.t, r.first) + ".." + constant(r.t, r.last)
+}
+
+// VisitCase checks whether the expression is of an ordinal type and whether the labels are of
+// the same type. The labels are constants, so a label can't be empty or overlap with another
+// label of the statement.
+func (c *Checker) VisitCase(node *ast.Case) (interface{}, error) {
+	t := c.expression(node.Expression)
+	if t != nil && !isOrdinal(t) {
+		c.fail(node.Expression, "expected the expression of CASE to be of an ordinal type, got %s", t)
+		t = nil
+	}
+
+	// Every range is checked against the ranges of the preceding labels.
+	var ranges []caseRange
+	for _, branch := range node.Branches {
+		for _, label := range branch.Labels {
+			current, ok := c.label(label, t)
+			if !ok {
+				continue
+			}
+
+			for _, previous := range ranges {
+				if current.overlaps(previous) {
+					c.fail(label, "CASE label %s overlaps with label %s", current, previous)
+					break
+				}
+			}
+			ranges = append(ranges, current)
+		}
+		c.Walk(branch.Statement)
+	}
+
+	for _, statement := range node.Else {
+		c.Walk(statement)
+	}
+	return nil, nil
+}
+
+// label checks whether the bounds of the label are of the type t of the expression of the CASE
+// statement and returns the range of the label. False is returned when the label contains errors
+// or its range is empty.
+func (c *Checker) label(label *ast.CaseLabel, t *Type) (caseRange, bool) {
+	valid := t != nil
+	for _, bound := range []ast.Expr{label.Low, label.High} {
+		if bound == nil {
+			continue
+		}
+
+		labelType := c.expression(bound)
+		if t != nil && labelType != nil && labelType != t {
+			c.fail(bound, "expected CASE label to be of type %s, got %s", t, labelType)
+		}
+		valid = valid && labelType == t
+	}
+	if !valid {
+		return caseRange{}, false
+	}
+
+	first, ok := ordinal(label.Low)
+	last := first
+	if label.High != nil {
+		high, valid := ordinal(label.High)
+		last, ok = high, ok && valid
+	}
+	if !ok {
+		return caseRange{}, false
+	}
+
+	result := caseRange{label: label, t: t, first: first, last: last}
+	if first > last {
+		c.fail(label, "CASE label range %s is empty", result)
+		return caseRange{}, false
+	}
+	return result, true
+}
+
+// call checks the arguments against the parameters of the procedure or function. The symbol
+// of the procedure or function is returned, nil is returned when it can't be resolved.
+func (c *Checker) call(node ast.Node, name string, arguments []ast.Expr) *Symbol {
+	symbol := c.scope.Lookup(name)
+	switch {
+	case isRead(symbol):
+		c.read(node, name, arguments)
+		return symbol
+	case isWrite(symbol):
+		c.write(arguments)
+		return symbol
+	case isStep(symbol):
+		c.step(node, name, arguments)
+		return symbol
+	case symbol != nil && symbol.Builtin && symbol.Kind == Function:
+		c.function(node, name, arguments)
+		return symbol
+	}
+
+	types := make([]*Type, len(arguments))
+	for index, argument := range arguments {
+		types[index] = c.expression(argument)
+	}
+
+	if symbol == nil || (symbol.Kind != Procedure && symbol.Kind != Function) {
+		return nil
+	}
+
+	if len(arguments) != len(symbol.Parameters) {
+		c.fail(node, "%s expects %s, got %d", name, argumentCount(len(symbol.Parameters)), len(arguments))
+		return symbol
+	}
+
+	for index, parameter := range symbol.Parameters {
+		target := c.declared(parameter)
+		value := types[index]
+		if target == nil || value == nil {
+			continue
+		}
+
+		if isReference(parameter) {
+			variable, ok := arguments[index].(*ast.Variable)
+			if !ok {
+				c.fail(arguments[index], "expected a variable as argument for VAR parameter %s", parameter.Name)
+			} else if c.readOnly(variable) != "" {
+				c.fail(arguments[index], "unable to pass %s as argument for VAR parameter %s", variable.Name,
+					parameter.Name)
+			} else if owner := c.owner(variable); owner != "" && !identical(value, target) {
+				c.fail(arguments[index], "unable to pass %s field of %s as %s VAR parameter %s",
+					value, owner, target, parameter.Name)
+			} else if len(variable.Selectors) > 0 && !identical(value, target) {
+				c.fail(arguments[index], "unable to pass %s element of %s as %s VAR parameter %s",
+					value, variable.Name, target, parameter.Name)
+			} else if !identical(value, target) {
+				c.fail(arguments[index], "unable to pass %s variable %s as %s VAR parameter %s",
+					value, variable.Name, target, parameter.Name)
+			}
+			continue
+		}
+
+		if !assignable(target, value) {
+			c.fail(arguments[index], "unable to pass a value of type %s as %s parameter %s", value, target, parameter.Name)
+		}
+	}
+	return symbol
+}
+
+// read checks whether the arguments of Read or ReadLn are variables of a type that can be read,
+// which are INTEGER, REAL, CHAR and STRING.
+func (c *Checker) read(node ast.Node, name string, arguments []ast.Expr) {
+	for _, argument := range arguments {
+		t := c.expression(argument)
+		variable, ok := argument.(*ast.Variable)
+		if !ok {
+			c.fail(argument, "expected a variable as argument of %s", name)
+			continue
+		}
+		c.control(node, variable)
+		c.writable(variable)
+
+		if t != nil && !isNumeric(t) && !isText(t) {
+			c.fail(argument, "unable to read %s variable %s", t, variable.Name)
+		}
+	}
+}
+
+// write checks the field width and the number of decimals of the arguments of Write or WriteLn,
+// which have to be INTEGER. Only a REAL value can be written with a number of decimals.
+func (c *Checker) write(arguments []ast.Expr) {
+	for _, argument := range arguments {
+		param, ok := argument.(*ast.WriteParam)
+		if !ok {
+			if t := c.expression(argument); !isSimple(t) {
+				c.fail(argument, "unable to write a value of type %s", t)
+			}
+			continue
+		}
+
+		t := c.expression(param.Expression)
+		if !isSimple(t) {
+			c.fail(param.Expression, "unable to write a value of type %s", t)
+			t = nil
+		}
+		if width := c.expression(param.Width); width != nil && width != Integer {
+			c.fail(param.Width, "expected the field width to be an INTEGER, got %s", width)
+		}
+		if param.Precision == nil {
+			continue
+		}
+
+		if precision := c.expression(param.Precision); precision != nil && precision != Integer {
+			c.fail(param.Precision, "expected the number of decimals to be an INTEGER, got %s", precision)
+		}
+		if t != nil && t != Real {
+			c.fail(param.Precision, "only REAL values can be written with a number of decimals, got %s", t)
+		}
+	}
+}
+
+// step checks the arguments of Inc or Dec, which are a variable of an ordinal type and
+// optionally the INTEGER number of values the variable is incremented or decremented by.
+func (c *Checker) step(node ast.Node, name string, arguments []ast.Expr) {
+	types := make([]*Type, len(arguments))
+	for index, argument := range arguments {
+		types[index] = c.expression(argument)
+	}
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		c.fail(node, "%s expects 1 or 2 arguments, got %d", name, len(arguments))
+		return
+	}
+
+	variable, ok := arguments[0].(*ast.Variable)
+	if !ok {
+		c.fail(arguments[0], "expected a variable as argument of %s", name)
+	} else if types[0] != nil && !isOrdinal(types[0]) {
+		c.fail(arguments[0], "%s expects a variable of an ordinal type, got %s", name, types[0])
+	}
+	if ok {
+		c.control(node, variable)
+		c.writable(variable)
+	}
+	if len(arguments) == 2 && types[1] != nil && types[1] != Integer {
+		c.fail(arguments[1], "%s expects an INTEGER as second argument, got %s", name, types[1])
+	}
+}
+
+// function checks the argument of the standard function against the standard functions of the
+// interpreter and returns the type of its result, nil is returned when the argument isn't
+// accepted.
+func (c *Checker) function(node ast.Node, name string, arguments []ast.Expr) *Type {
+	types := make([]*Type, len(arguments))
+	for index, argument := range arguments {
+		types[index] = c.expression(argument)
+	}
+
+	standard := visitor.Functions[name]
+	if len(arguments) != standard.Arity {
+		c.fail(node, "%s expects %s, got %d", name, argumentCount(standard.Arity), len(arguments))
+		return nil
+	}
+	if types[0] == nil {
+		return nil
+	}
+
+	if !accepts(standard.Argument, types[0]) {
+		c.fail(arguments[0], "%s expects %s, got %s", name, standard.Argument.Description, types[0])
+		return nil
+	}
+	if standard.Result == "" {
+		return types[0]
+	}
+	return typeNamed(standard.Result)
+}
+
+// accepts reports whether the type is one of the standard types the argument of a standard
+// function can have.
+func accepts(argument visitor.Argument, t *Type) bool {
+	for _, name := range argument.Types {
+		if typeNamed(name) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// VisitTypeDecl checks the type of the declaration. A RECORD type is named after the declaration
+// that describes it.
+func (c *Checker) VisitTypeDecl(node *ast.TypeDecl) (interface{}, error) {
+	t := c.typeOf(node.Type)
+	if isRecord(t) && node.Type.Token.Type == token.Record {
+		t.Name = node.Name
+	}
+	return nil, nil
+}
+
+// VisitTypeSpec checks the ranges of the indexes of an ARRAY type.
+func (c *Checker) VisitTypeSpec(node *ast.TypeSpec) (interface{}, error) {
+	c.typeOf(node)
+	return nil, nil
+}
+
+// typeOf returns the type described by the type specification, nil is returned when it contains
+// errors. An ARRAY type with more than one range of indexes is an ARRAY of ARRAY types. A name
+// declared in a TYPE section describes the type of its declaration.
+func (c *Checker) typeOf(spec *ast.TypeSpec) *Type {
+	if spec == nil {
+		return nil
+	}
+	if t, ok := c.specs[spec]; ok {
+		return t
+	}
+
+	t := typeNamed(spec.Name)
+	switch {
+	case spec.Token.Type == token.Identifier:
+		// The analyzer reports the names that aren't declared as a type.
+		t = nil
+		symbol := c.scope.Lookup(spec.Name)
+		if symbol == nil || symbol.Kind != Definition {
+			break
+		}
+		c.specs[spec] = nil
+		t = c.typeOf(symbol.Declaration.(*ast.TypeDecl).Type)
+	case spec.Fields != nil:
+		t = &Type{Name: "RECORD", Fields: make([]Member, 0, len(spec.Fields))}
+		for _, field := range spec.Fields {
+			member := c.typeOf(field.Type)
+			if member == nil {
+				t = nil
+				break
+			}
+			t.Fields = append(t.Fields, Member{Name: field.Variable.Name, Type: member})
+		}
+	case spec.Element != nil:
+		t = c.typeOf(spec.Element)
+		for index := len(spec.Ranges) - 1; index >= 0; index-- {
+			indexType, low, high := c.subrange(spec.Ranges[index])
+			if t == nil || indexType == nil {
+				t = nil
+				continue
+			}
+			t = newArray(indexType, low, high, t)
+		}
+	}
+
+	c.specs[spec] = t
+	return t
+}
+
+// subrange checks whether the bounds of the range of indexes are constants of the same ordinal
+// type and whether the range isn't empty. The type and the ordinal numbers of the bounds are
+// returned, the type is nil when the range contains errors.
+func (c *Checker) subrange(subrange *ast.Subrange) (*Type, int, int) {
+	lowType, low := c.constant(subrange.Low)
+	highType, high := c.constant(subrange.High)
+	if lowType == nil || highType == nil {
+		return nil, 0, 0
+	}
+
+	if lowType != highType {
+		c.fail(subrange, "expected the bounds of an index range to be of the same type, got %s and %s",
+			lowType, highType)
+		return nil, 0, 0
+	}
+	if low > high {
+		c.fail(subrange, "index range %s..%s is empty", constant(lowType, low), constant(lowType, high))
+		return nil, 0, 0
+	}
+	return lowType, low, high
+}
+
+// constant returns the ordinal type and the ordinal number of a bound of a range of indexes,
+// the type is nil when the bound isn't a constant of an ordinal type.
+func (c *Checker) constant(bound ast.Expr) (*Type, int) {
+	t := c.expression(bound)
+	if t == nil {
+		return nil, 0
+	}
+
+	value, ok := ordinal(bound)
+	if ok && isOrdinal(t) {
+		return t, value
+	}
+	if num, isNum := literal(bound).(*ast.Num); isNum && t == Integer {
+		c.fail(num, "integer %s is out of range", num.Lexeme)
+		return nil, 0
+	}
+
+	c.fail(bound, "expected the bounds of an index range to be of an ordinal type, got %s", t)
+	return nil, 0
+}
+
+// literal returns the literal of the constant without its sign.
+func literal(bound ast.Expr) ast.Expr {
+	if unary, ok := bound.(*ast.UnaryOp); ok {
+		return unary.Expression
+	}
+	return bound
+}
+
+// ordinal returns the ordinal number of the constant, false is returned when the constant isn't
+// a literal of an ordinal type or an integer that is out of range.
+func ordinal(bound ast.Expr) (int, bool) {
+	sign := 1
+	if unary, ok := bound.(*ast.UnaryOp); ok && unary.Operator.Type == token.Sub {
+		sign = -1
+	}
+
+	switch b := literal(bound).(type) {
+	case *ast.Num:
+		value, err := strconv.Atoi(b.Lexeme)
+		return sign * value, err == nil
+	case *ast.Boolean:
+		if b.Value {
+			return 1, true
+		}
+		return 0, true
+	case *ast.Char:
+		return int(b.Value), true
+	}
+	return 0, false
+}
+
+// declared returns the declared type of the variable or parameter, or the type of the result of
+// the function.
+func (c *Checker) declared(symbol *Symbol) *Type {
+	switch declaration := symbol.Declaration.(type) {
+	case *ast.VarDecl:
+		return c.typeOf(declaration.Type)
+	case *ast.Param:
+		return c.typeOf(declaration.Type)
+	case *ast.FunctionDecl:
+		return c.typeOf(declaration.ReturnType)
+	}
+	return typeNamed(symbol.Type)
+}
+
+// element checks the selectors of the variable and returns the type of the element or field they
+// select from a value of the type. Every index selects an element of an ARRAY and has to be of
+// the type of its indexes, every field has to be a field of a RECORD. Nil is returned when the
+// value can't be selected from.
+func (c *Checker) element(variable *ast.Variable, t *Type) *Type {
+	kind, name := "variable", variable.Name
+	if _, record := c.field(variable.Name); record != nil {
+		kind = "field"
+	}
+
+	declared, position := t, 0
+	for _, selector := range variable.Selectors {
+		if field, ok := selector.(*ast.Field); ok {
+			if t == nil {
+				continue
+			}
+			if t.field(field.Name) == nil {
+				c.fail(field, "%s has no field %s", t, field.Name)
+				t = nil
+				continue
+			}
+
+			t = t.field(field.Name)
+			kind, name = "field", field.Name
+			declared, position = t, 0
+			continue
+		}
+
+		indexType := c.expression(selector)
+		if t == nil {
+			continue
+		}
+
+		if !isArray(t) {
+			if position == 0 {
+				c.fail(selector, "unable to index %s %s %s", t, kind, name)
+			} else {
+				c.fail(selector, "too many indexes for %s %s %s", declared, kind, name)
+			}
+			t = nil
+			continue
+		}
+		if indexType != nil && indexType != t.Index {
+			c.fail(selector, "expected an index of type %s, got %s", t.Index, indexType)
+		}
+		t = t.Element
+		position++
+	}
+	return t
+}
+
+// field returns the type of the field with the name of the innermost WITH statement whose
+// RECORD variable has such a field, together with that variable. The variable is nil when no
+// WITH statement has a field with the name.
+func (c *Checker) field(name string) (*Type, *ast.Variable) {
+	for index := len(c.with) - 1; index >= 0; index-- {
+		variable := c.with[index]
+		if t := c.Types[variable]; isRecord(t) && t.field(name) != nil {
+			return t.field(name), variable
+		}
+	}
+	return nil, nil
+}
+
+// owner returns the name of the RECORD variable of which the variable selects a field, either
+// through its selectors or through a WITH statement. The name is empty when the variable doesn't
+// select a field.
+func (c *Checker) owner(variable *ast.Variable) string {
+	if _, record := c.field(variable.Name); record != nil {
+		return record.Name
+	}
+	for _, selector := range variable.Selectors {
+		if _, ok := selector.(*ast.Field); ok {
+			return variable.Name
+		}
+	}
+	return ""
+}
+
+// VisitWith checks whether the variables of the WITH statement are RECORD variables and checks
+// the body, in which their fields can be accessed by their names.
+func (c *Checker) VisitWith(node *ast.With) (interface{}, error) {
+	count := len(c.with)
+	for _, variable := range node.Variables {
+		t := c.expression(variable)
+		if t != nil && !isRecord(t) {
+			c.fail(variable, "expected a RECORD variable in WITH, got %s", t)
+		}
+		c.with = append(c.with, variable)
+	}
+
+	c.Walk(node.Body)
+	c.with = c.with[:count]
+	return nil, nil
+}
+
+// expression determines the type of the expression and records it in Types. Nil is returned
+// when the expression contains type errors, which are only reported once.
+func (c *Checker) expression(expression ast.Expr) *Type {
+	if expression == nil {
+		return nil
+	}
+
+	result, _ := expression.Accept(c)
+	t, _ := result.(*Type)
+	if t != nil {
+		c.Types[expression] = t
+	}
+	return t
+}
+
+func (c *Checker) VisitNum(node *ast.Num) (interface{}, error) {
+	if node.Token.Type == token.Real {
+		return Real, nil
+	}
+	return Integer, nil
+}
+
+func (c *Checker) VisitBoolean(node *ast.Boolean) (interface{}, error) {
+	return Boolean, nil
+}
+
+func (c *Checker) VisitChar(node *ast.Char) (interface{}, error) {
+	return Char, nil
+}
+
+func (c *Checker) VisitString(node *ast.String) (interface{}, error) {
+	return String, nil
+}
+
+// VisitVariable returns the declared type of the variable, or the type of the element or field
+// the selectors select. Inside a WITH statement the name of a field refers to the field of its
+// RECORD variable. The name of a function without parameters calls the function.
+func (c *Checker) VisitVariable(node *ast.Variable) (interface{}, error) {
+	if t, record := c.field(node.Name); record != nil {
+		return c.element(node, t), nil
+	}
+
+	symbol := c.scope.Lookup(node.Name)
+	if symbol == nil {
+		return c.element(node, nil), nil
+	}
+
+	switch symbol.Kind {
+	case Definition:
+		c.fail(node, "unable to use type %s as a value", node.Name)
+		return c.element(node, nil), nil
+	case Procedure, Function:
+		if symbol.Builtin && symbol.Kind == Function {
+			return c.element(node, c.function(node, symbol.Name, nil)), nil
+		}
+		if len(symbol.Parameters) > 0 {
+			c.fail(node, "%s expects %s, got 0", symbol.Name, argumentCount(len(symbol.Parameters)))
+			return c.element(node, nil), nil
+		}
+		return c.element(node, c.result(node, symbol)), nil
+	}
+	return c.element(node, c.declared(symbol)), nil
+}
+
+// VisitFunctionCall checks the arguments and returns the type of the result of the function.
+// The type of the result of a standard function depends on the type of its argument.
+func (c *Checker) VisitFunctionCall(node *ast.FunctionCall) (interface{}, error) {
+	if symbol := c.scope.Lookup(node.Name); symbol != nil && symbol.Builtin && symbol.Kind == Function {
+		return c.function(node, node.Name, node.Arguments), nil
+	}
+
+	symbol := c.call(node, node.Name, node.Arguments)
+	return c.result(node, symbol), nil
+}
+
+// result returns the type of the result of the function.
+func (c *Checker) result(node ast.Node, symbol *Symbol) *Type {
+	if symbol == nil {
+		return nil
+	}
+	if symbol.Kind == Procedure {
+		c.fail(node, "procedure %s doesn't return a value", symbol.Name)
+		return nil
+	}
+	return c.declared(symbol)
+}
+
+// VisitWriteParam reports a field width in the arguments of a procedure other than Write and
+// WriteLn, which check their arguments themselves.
+func (c *Checker) VisitWriteParam(node *ast.WriteParam) (interface{}, error) {
+	c.fail(node, "only the arguments of Write and WriteLn can have a field width")
+	return nil, nil
+}
+
+// VisitUnaryOp determines the type of a unary operation, the sign operators require a number and
+// NOT requires a BOOLEAN.
+func (c *Checker) VisitUnaryOp(expression *ast.UnaryOp) (interface{}, error) {
+	return c.unary(expression), nil
+}
+
+func (c *Checker) unary(expression *ast.UnaryOp) *Type {
+	operand := c.expression(expression.Expression)
+	if operand == nil {
+		return nil
+	}
+
+	if expression.Operator.Type == token.Not {
+		if operand != Boolean {
+			c.fail(expression.Expression, "operator NOT expects a BOOLEAN operand, got %s", operand)
+			return nil
+		}
+		return Boolean
+	}
+
+	if !isNumeric(operand) {
+		c.fail(expression.Expression, "operator %s expects an INTEGER or REAL operand, got %s",
+			expression.Operator.Lexeme, operand)
+		return nil
+	}
+	return operand
+}
+
+// VisitBinOp determines the type of a binary operation. Arithmetic on two integers results in an
+// INTEGER and arithmetic involving a REAL results in a REAL, except for the division operator
+// which always results in a REAL. Adding characters and strings results in a STRING.
+func (c *Checker) VisitBinOp(expression *ast.BinOp) (interface{}, error) {
+	return c.binary(expression), nil
+}
+
+func (c *Checker) binary(expression *ast.BinOp) *Type {
+	left := c.expression(expression.Left)
+	right := c.expression(expression.Right)
+	if left == nil || right == nil {
+		return nil
+	}
+
+	operator := strings.ToUpper(expression.Operator.Lexeme)
+	if expression.Operator.Type == token.Add && (isText(left) || isText(right)) {
+		if !isText(left) || !isText(right) {
+			c.fail(expression, "operator + expects CHAR or STRING operands, got %s and %s", left, right)
+			return nil
+		}
+		return String
+	}
+
+	switch expression.Operator.Type {
+	case token.Add, token.Sub, token.Mul, token.Div:
+		if !isNumeric(left) || !isNumeric(right) {
+			c.fail(expression, "operator %s expects INTEGER or REAL operands, got %s and %s", operator, left, right)
+			return nil
+		}
+		if expression.Operator.Type == token.Div || left == Real || right == Real {
+			return Real
+		}
+		return Integer
+	case token.IntDiv, token.Mod:
+		if left != Integer || right != Integer {
+			c.fail(expression, "operator %s expects INTEGER operands, got %s and %s", operator, left, right)
+			return nil
+		}
+		return Integer
+	case token.And, token.Or:
+		if left != Boolean || right != Boolean {
+			c.fail(expression, "operator %s expects BOOLEAN operands, got %s and %s", operator, left, right)
+			return nil
+		}
+		return Boolean
+	case token.Equal, token.NotEqual, token.Less, token.LessEqual, token.Greater, token.GreaterEqual:
+		if !isSimple(left) || !isSimple(right) || left != right && !(isNumeric(left) && isNumeric(right)) && !(isText(left) && isText(right)) {
+			c.fail(expression, "unable to compare %s with %s", left, right)
+			return nil
+		}
+		return Boolean
+	}
+	return nil
+}
+
+// fail adds the error to the type errors.
+func (c *Checker) fail(node ast.Node, format string, args ...interface{}) {
+	c.errors = append(c.errors, newSemanticError(node, format, args...))
+}
@@ -0,0 +1,47 @@
+package semantic
+
+import (
+	"fmt"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+)
+
+// SemanticError is reported when a program is syntactically correct but meaningless, for
+// instance when it uses an identifier that isn't declared. The span refers to the offending
+// identifier or declaration.
+type SemanticError struct {
+	Message string
+	Span    token.Span
+}
+
+func newSemanticError(node ast.Node, format string, args ...interface{}) *SemanticError {
+	return &SemanticError{
+		Message: fmt.Sprintf(format, args...),
+		Span:    ast.SpanOf(node),
+	}
+}
+
+func (e *SemanticError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Span.Start, e.Message)
+}
+
+// ErrorList is a list of semantic errors, in the order they were found in the program.
+type ErrorList []*SemanticError
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// argumentCount formats the number of arguments, for instance 1 argument or 2 arguments.
+func argumentCount(count int) string {
+	if count == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", count)
+}
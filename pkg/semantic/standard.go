@@ -0,0 +1,47 @@
+package semantic
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/visitor"
+)
+
+// procedures contains the names of the standard procedures.
+var procedures = []string{"read", "readln", "write", "writeln", "inc", "dec"}
+
+// standard creates the scope of the standard types, procedures and functions, which encloses
+// the scope of the program. The program can declare an identifier with the same name as a
+// standard type, procedure or function, which hides the standard one.
+func standard() *Scope {
+	scope := NewScope("", nil, nil)
+	scope.Level = -1
+
+	for name := range builtins {
+		declaration := &ast.TypeDecl{Name: name, Type: &ast.TypeSpec{Name: name}}
+		scope.Insert(&Symbol{Name: name, Kind: Definition, Type: name, Declaration: declaration, Builtin: true})
+	}
+	for _, name := range procedures {
+		scope.Insert(&Symbol{Name: name, Kind: Procedure, Builtin: true})
+	}
+	for name := range visitor.Functions {
+		scope.Insert(&Symbol{Name: name, Kind: Function, Builtin: true})
+	}
+	return scope
+}
+
+// isRead reports whether the symbol is the standard procedure Read or ReadLn, which assign a
+// value read from the input to every argument.
+func isRead(symbol *Symbol) bool {
+	return symbol != nil && symbol.Builtin && (symbol.Name == "read" || symbol.Name == "readln")
+}
+
+// isWrite reports whether the symbol is the standard procedure Write or WriteLn, which write
+// every argument to the output.
+func isWrite(symbol *Symbol) bool {
+	return symbol != nil && symbol.Builtin && (symbol.Name == "write" || symbol.Name == "writeln")
+}
+
+// isStep reports whether the symbol is the standard procedure Inc or Dec, which increment or
+// decrement the ordinal variable that is passed as first argument.
+func isStep(symbol *Symbol) bool {
+	return symbol != nil && symbol.Builtin && (symbol.Name == "inc" || symbol.Name == "dec")
+}
@@ -0,0 +1,111 @@
+package semantic
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// Kind distinguishes the kinds of identifiers that can be declared.
+type Kind int
+
+const (
+	Variable Kind = iota
+	Parameter
+	Procedure
+	Function
+	Definition
+	Field
+)
+
+var kinds = map[Kind]string{
+	Variable:   "variable",
+	Parameter:  "parameter",
+	Procedure:  "procedure",
+	Function:   "function",
+	Definition: "type",
+	Field:      "field",
+}
+
+func (k Kind) String() string {
+	return kinds[k]
+}
+
+// Symbol is a declared identifier. Type is the name of the type of a variable, parameter or
+// field, the type of the result of a function or the type a type declaration stands for. A name
+// declared in a TYPE section is replaced by the name of the type it stands for, for instance
+// record. Parameters contains the parameters of a procedure or function in the order they are
+// declared.
+type Symbol struct {
+	Name        string
+	Kind        Kind
+	Type        string
+	Parameters  []*Symbol
+	Declaration ast.Node
+	// Scope is the scope the symbol is declared in.
+	Scope *Scope
+	// Builtin is set for the standard types, procedures and functions, which are provided by the
+	// interpreter instead of declared in the program.
+	Builtin bool
+}
+
+// Scope is a symbol table containing the identifiers declared in the program, a procedure or a
+// function. The identifiers of the enclosing scopes are visible unless a nested scope declares
+// an identifier with the same name.
+type Scope struct {
+	Name    string
+	Level   int
+	Symbols map[string]*Symbol
+	// Owner is the procedure or function the scope belongs to, it's nil for the program scope.
+	Owner    *Symbol
+	Parent   *Scope
+	Children []*Scope
+}
+
+// NewScope creates an empty scope nested in the parent, the parent is nil for the program scope.
+func NewScope(name string, owner *Symbol, parent *Scope) *Scope {
+	scope := &Scope{
+		Name:    name,
+		Symbols: make(map[string]*Symbol),
+		Owner:   owner,
+		Parent:  parent,
+	}
+
+	if parent != nil {
+		scope.Level = parent.Level + 1
+		parent.Children = append(parent.Children, scope)
+	}
+	return scope
+}
+
+// Insert declares the symbol in the scope. False is returned when the scope already contains
+// an identifier with the same name.
+func (s *Scope) Insert(symbol *Symbol) bool {
+	if _, ok := s.Symbols[symbol.Name]; ok {
+		return false
+	}
+
+	symbol.Scope = s
+	s.Symbols[symbol.Name] = symbol
+	return true
+}
+
+// Lookup returns the symbol with the name from the nearest scope that declares it, nil is
+// returned when the identifier isn't declared.
+func (s *Scope) Lookup(name string) *Symbol {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if symbol, ok := scope.Symbols[name]; ok {
+			return symbol
+		}
+	}
+	return nil
+}
+
+// Encloses reports whether the scope is the scope of the procedure or function, or is nested
+// in it.
+func (s *Scope) Encloses(owner *Symbol) bool {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if scope.Owner == owner {
+			return true
+		}
+	}
+	return false
+}
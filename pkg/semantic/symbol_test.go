@@ -0,0 +1,48 @@
+package semantic_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/semantic"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScope_Insert(t *testing.T) {
+	global := semantic.NewScope("program", nil, nil)
+
+	x := &semantic.Symbol{Name: "x", Kind: semantic.Variable, Type: "integer"}
+	assert.True(t, global.Insert(x))
+	assert.Equal(t, global, x.Scope)
+
+	assert.False(t, global.Insert(&semantic.Symbol{Name: "x", Kind: semantic.Procedure}))
+	assert.Equal(t, x, global.Symbols["x"])
+}
+
+func TestScope_Lookup(t *testing.T) {
+	global := semantic.NewScope("program", nil, nil)
+	x := &semantic.Symbol{Name: "x", Kind: semantic.Variable, Type: "integer"}
+	p := &semantic.Symbol{Name: "p", Kind: semantic.Procedure}
+	global.Insert(x)
+	global.Insert(p)
+
+	local := semantic.NewScope("p", p, global)
+	shadow := &semantic.Symbol{Name: "x", Kind: semantic.Parameter, Type: "real"}
+	local.Insert(shadow)
+
+	assert.Equal(t, 1, local.Level)
+	assert.Equal(t, []*semantic.Scope{local}, global.Children)
+
+	assert.Equal(t, shadow, local.Lookup("x"))
+	assert.Equal(t, x, global.Lookup("x"))
+	assert.Equal(t, p, local.Lookup("p"))
+	assert.Nil(t, local.Lookup("y"))
+
+	assert.True(t, local.Encloses(p))
+	assert.False(t, global.Encloses(p))
+}
+
+func TestKind_String(t *testing.T) {
+	assert.Equal(t, "variable", semantic.Variable.String())
+	assert.Equal(t, "parameter", semantic.Parameter.String())
+	assert.Equal(t, "procedure", semantic.Procedure.String())
+	assert.Equal(t, "function", semantic.Function.String())
+}
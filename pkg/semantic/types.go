@@ -0,0 +1,141 @@
+package semantic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Type is the static type of an expression or the declared type of a variable. An ARRAY type
+// has the ordinal type of its indexes, the ordinal numbers of its first and last index and the
+// type of its elements. An ARRAY type with more than one dimension is an ARRAY of ARRAY types.
+// A RECORD type has its fields in the order they are declared.
+type Type struct {
+	Name    string
+	Index   *Type
+	Low     int
+	High    int
+	Element *Type
+	Fields  []Member
+}
+
+// Member is a field of a RECORD type.
+type Member struct {
+	Name string
+	Type *Type
+}
+
+func (t *Type) String() string {
+	return t.Name
+}
+
+// The built-in types.
+var (
+	Integer = &Type{Name: "INTEGER"}
+	Real    = &Type{Name: "REAL"}
+	Boolean = &Type{Name: "BOOLEAN"}
+	Char    = &Type{Name: "CHAR"}
+	String  = &Type{Name: "STRING"}
+)
+
+var builtins = map[string]*Type{
+	"integer": Integer,
+	"real":    Real,
+	"boolean": Boolean,
+	"char":    Char,
+	"string":  String,
+}
+
+// typeNamed returns the built-in type with the name, nil is returned when there is none.
+func typeNamed(name string) *Type {
+	return builtins[strings.ToLower(name)]
+}
+
+// newArray creates the ARRAY type with the indexes from low to high.
+func newArray(index *Type, low int, high int, element *Type) *Type {
+	name := fmt.Sprintf("ARRAY[%s..%s] OF %s", constant(index, low), constant(index, high), element)
+	return &Type{Name: name, Index: index, Low: low, High: high, Element: element}
+}
+
+// constant formats the value of the ordinal type with the ordinal number the way it's written
+// in the source code.
+func constant(t *Type, ordinal int) string {
+	switch t {
+	case Boolean:
+		if ordinal == 1 {
+			return "TRUE"
+		}
+		return "FALSE"
+	case Char:
+		return fmt.Sprintf("'%c'", rune(ordinal))
+	}
+	return fmt.Sprint(ordinal)
+}
+
+// field returns the type of the field of the RECORD type with the name, nil is returned when the
+// type has no such field.
+func (t *Type) field(name string) *Type {
+	for _, member := range t.Fields {
+		if member.Name == name {
+			return member.Type
+		}
+	}
+	return nil
+}
+
+// isArray reports whether the type is an ARRAY type.
+func isArray(t *Type) bool {
+	return t != nil && t.Element != nil
+}
+
+// isRecord reports whether the type is a RECORD type.
+func isRecord(t *Type) bool {
+	return t != nil && t.Fields != nil
+}
+
+// isSimple reports whether the type is neither an ARRAY nor a RECORD type.
+func isSimple(t *Type) bool {
+	return !isArray(t) && !isRecord(t)
+}
+
+// identical reports whether both types are the same type. ARRAY types are the same when their
+// indexes and their elements are of the same type. RECORD types are the same when they have
+// fields with the same names and types in the same order.
+func identical(first *Type, second *Type) bool {
+	if first == second {
+		return true
+	}
+	if isRecord(first) && isRecord(second) && len(first.Fields) == len(second.Fields) {
+		for index, member := range first.Fields {
+			other := second.Fields[index]
+			if member.Name != other.Name || !identical(member.Type, other.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return isArray(first) && isArray(second) && first.Index == second.Index && first.Low == second.Low &&
+		first.High == second.High && identical(first.Element, second.Element)
+}
+
+// isNumeric reports whether the type is INTEGER or REAL.
+func isNumeric(t *Type) bool {
+	return t == Integer || t == Real
+}
+
+// isText reports whether the type is CHAR or STRING.
+func isText(t *Type) bool {
+	return t == Char || t == String
+}
+
+// isOrdinal reports whether the values of the type can be counted, which are the INTEGER,
+// BOOLEAN and CHAR values.
+func isOrdinal(t *Type) bool {
+	return t == Integer || t == Boolean || t == Char
+}
+
+// assignable reports whether a value of the type can be assigned to a variable of the target
+// type. The types have to be the same, except for INTEGER values which are widened to REAL and
+// CHAR values which are widened to STRING.
+func assignable(target *Type, value *Type) bool {
+	return identical(target, value) || (target == Real && value == Integer) || (target == String && value == Char)
+}
@@ -0,0 +1,154 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"strings"
+)
+
+func (v *Visitor) VisitAssign(statement *ast.Assign) (interface{}, error) {
+	variable, ok := statement.Left.(*ast.Variable)
+	if !ok {
+		return nil, newRuntimeError(statement, "expected left side of assignment to be a variable")
+	}
+
+	value, err := v.evaluate(statement.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, ok, err := v.element(variable)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, v.storeElement(statement, selected, value)
+	}
+
+	record, err := v.target(variable)
+	if err != nil {
+		return nil, err
+	}
+	return nil, v.store(statement, record, variable, value)
+}
+
+// target returns the record the variable is assigned in, which is the nearest scope that
+// contains it. A variable that isn't declared at all is assigned in the current scope.
+func (v *Visitor) target(variable *ast.Variable) (*ActivationRecord, error) {
+	record := v.Stack.Peek().Resolve(variable.Name)
+	if record == nil {
+		if declaration, _ := v.Stack.Peek().Callable(variable.Name); declaration != nil {
+			return nil, newRuntimeError(variable, "unable to assign to %s, it isn't a variable", variable.Name)
+		}
+		record = v.Stack.Peek()
+	}
+
+	// The semantic checker rejects the assignment of CONST parameters before the program runs,
+	// this guards the programs that weren't checked.
+	if record.ReadOnly[variable.Name] {
+		return nil, newRuntimeError(variable, "unable to assign to CONST parameter %s", variable.Name)
+	}
+	return record, nil
+}
+
+// store converts the value to the declared type of the variable and assigns it in the record,
+// a value that can't be converted results in an error at the position of the node.
+func (v *Visitor) store(node ast.Node, record *ActivationRecord, variable *ast.Variable, value Value) error {
+	declared := record.Types[variable.Name]
+	converted, ok := convert(value, declared)
+	if !ok {
+		return newRuntimeError(node, "unable to assign a value of type %s to %s variable %s",
+			typeName(value), strings.ToUpper(declared), variable.Name)
+	}
+
+	storage, key := record.Storage(variable.Name)
+	current := storage.Memory[key]
+	if described := incompatible(current, converted); described != "" {
+		return newRuntimeError(node, "unable to assign %s value of another type to variable %s", described,
+			variable.Name)
+	}
+	storage.Memory[key] = replace(current, converted)
+	return nil
+}
+
+// element returns the element or field of the variable that its selectors select, or the field
+// of a WITH statement with the name of the variable. False is returned when the variable selects
+// neither.
+func (v *Visitor) element(variable *ast.Variable) (element, bool, error) {
+	return v.locate(variable, func() (Value, error) {
+		record, err := v.target(variable)
+		if err != nil {
+			return nil, err
+		}
+		storage, key := record.Storage(variable.Name)
+		return storage.Memory[key], nil
+	})
+}
+
+// storeElement converts the value to the declared type of the element or field and assigns it.
+func (v *Visitor) storeElement(node ast.Node, element element, value Value) error {
+	declared := element.declared()
+	converted, ok := convert(value, declared)
+	if !ok {
+		return newRuntimeError(node, "unable to assign a value of type %s to %s %s %s",
+			typeName(value), strings.ToUpper(declared), element.kind(), element.Name)
+	}
+
+	current := element.value()
+	if described := incompatible(current, converted); described != "" {
+		return newRuntimeError(node, "unable to assign %s value of another type to %s %s", described,
+			element.kind(), element.Name)
+	}
+	element.set(replace(current, converted))
+	return nil
+}
+
+// incompatible describes the current value of a variable when the value can't replace it, for
+// instance an ARRAY. An array can only be replaced by an array of the same type and a record by
+// a record of the same type. The description is empty when the value can replace it.
+func incompatible(current Value, value Value) string {
+	switch current.(type) {
+	case *Array:
+		if !sameType(current, value) {
+			return "an ARRAY"
+		}
+	case *Record:
+		if !sameType(current, value) {
+			return "a RECORD"
+		}
+	}
+	return ""
+}
+
+// replace returns the value that replaces the current value of a variable. A record is replaced
+// in place, so a WITH statement that refers to the record sees the fields of the value.
+func replace(current Value, value Value) Value {
+	record, ok := current.(*Record)
+	other, otherOk := value.(*Record)
+	if !ok || !otherOk {
+		return value
+	}
+
+	record.Fields = other.Fields
+	return record
+}
+
+// convert converts the value to the declared type. The value has to be of the declared type,
+// except for integers which are widened to REAL and characters which are widened to STRING.
+// Any value can be converted when there is no declared type. Arrays and records are copied, so
+// the variable doesn't share its elements with the value. False is returned when the value
+// can't be converted.
+func convert(value Value, declared string) (Value, bool) {
+	if declared == "" || strings.EqualFold(declared, typeName(value)) {
+		return copyValue(value), true
+	}
+
+	integer, ok := value.(Integer)
+	if ok && declared == "real" {
+		return integer.Real(), true
+	}
+	character, ok := value.(Char)
+	if ok && declared == "string" {
+		return toString(character), true
+	}
+	return nil, false
+}
@@ -0,0 +1,68 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitAssign(t *testing.T) {
+	input := &ast.Assign{
+		Left: &ast.Variable{
+			Name: "x",
+			Token: token.Token{
+				Type:   token.Identifier,
+				Lexeme: "x",
+			},
+		},
+		Operator: token.Token{
+			Type:   token.Assign,
+			Lexeme: ":=",
+		},
+		Right: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: "12",
+			},
+			Lexeme: "12",
+		},
+	}
+
+	memory := make(map[string]visitor2.Value)
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(12), memory["x"])
+}
+
+func TestVisitor_VisitAssign_Element(t *testing.T) {
+	array := &visitor2.Array{Low: 0, Elements: make([]visitor2.Value, 2), IndexType: "integer", ElementType: "real"}
+	memory := map[string]visitor2.Value{"a": array}
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+
+	element := &ast.Variable{Name: "a", Selectors: []ast.Expr{number("1")}}
+	_, err := visitor.Visit(&ast.Assign{Left: element, Right: number("12")})
+	assert.Nil(t, err)
+	assert.Equal(t, []visitor2.Value{nil, visitor2.Real(12)}, array.Elements)
+
+	_, err = visitor.Visit(&ast.Assign{Left: element, Right: &ast.Boolean{Value: true}})
+	assert.EqualError(t, err, "0:0: unable to assign a value of type BOOLEAN to REAL element a[1]")
+
+	element.Selectors[0] = number("2")
+	_, err = visitor.Visit(&ast.Assign{Left: element, Right: number("12")})
+	assert.EqualError(t, err, "0:0: index 2 of a is out of range 0..1")
+
+	// Assigning an array copies its elements.
+	memory["b"] = &visitor2.Array{Low: 0, Elements: make([]visitor2.Value, 2), IndexType: "integer", ElementType: "real"}
+	_, err = visitor.Visit(&ast.Assign{Left: &ast.Variable{Name: "b"}, Right: &ast.Variable{Name: "a"}})
+	assert.Nil(t, err)
+	assert.Equal(t, array, memory["b"])
+	assert.NotSame(t, array, memory["b"])
+
+	memory["c"] = &visitor2.Array{Low: 1, Elements: make([]visitor2.Value, 2), IndexType: "integer", ElementType: "real"}
+	_, err = visitor.Visit(&ast.Assign{Left: &ast.Variable{Name: "c"}, Right: &ast.Variable{Name: "a"}})
+	assert.EqualError(t, err, "0:0: unable to assign an ARRAY value of another type to variable c")
+}
@@ -1,46 +1,164 @@
 package visitor
 
 import (
-	"errors"
-	"fmt"
 	"github.com/njirem95/simple-pascal/pkg/ast"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"strings"
 )
 
-type BinOpVisitor struct {
-}
+// VisitBinOp evaluates the binary operation. An operation on two integers results in an integer,
+// the integer operand of an operation on an integer and a real is promoted to a real. The
+// division operator always results in a real, DIV and MOD only accept integers. Relational
+// operators and the logical operators AND and OR result in a boolean. Adding characters and
+// strings concatenates them into a string.
+func (v *Visitor) VisitBinOp(expression *ast.BinOp) (interface{}, error) {
+	left, err := v.evaluate(expression.Left)
+	if err != nil {
+		return nil, err
+	}
 
-func (b *BinOpVisitor) Visit(expression *ast.BinOp) (int, error) {
-	visitor := Visitor{}
+	if expression.Operator.Type == token.And || expression.Operator.Type == token.Or {
+		return v.logical(expression, left)
+	}
 
-	node, err := visitor.Visit(expression.Left)
+	right, err := v.evaluate(expression.Right)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	switch expression.Operator.Type {
+	case token.Equal, token.NotEqual, token.Less, token.LessEqual, token.Greater, token.GreaterEqual:
+		return v.compare(expression, left, right)
+	}
+
+	if expression.Operator.Type == token.Add && (isText(left) || isText(right)) {
+		if !isText(left) || !isText(right) {
+			return nil, newRuntimeError(expression, "operator + expects CHAR or STRING operands, got %s and %s",
+				typeName(left), typeName(right))
+		}
+		return toString(left).Concat(toString(right)), nil
 	}
-	left, ok := node.(int)
+
+	leftNumber, ok := left.(Number)
+	if !ok {
+		return nil, newRuntimeError(expression.Left, "expected left to be a number, got %s", typeName(left))
+	}
+	rightNumber, ok := right.(Number)
 	if !ok {
-		return 0, errors.New("expected left to be an integer")
+		return nil, newRuntimeError(expression.Right, "expected right to be a number, got %s", typeName(right))
+	}
+
+	leftInteger, leftIsInteger := left.(Integer)
+	rightInteger, rightIsInteger := right.(Integer)
+	operator := strings.ToUpper(expression.Operator.Lexeme)
+
+	var result Value
+	switch {
+	// DIV and MOD are only defined for integers, both truncate towards zero.
+	case expression.Operator.Type == token.IntDiv || expression.Operator.Type == token.Mod:
+		if !leftIsInteger || !rightIsInteger {
+			return nil, newRuntimeError(expression, "operator %s expects INTEGER operands", operator)
+		}
+		if expression.Operator.Type == token.Mod {
+			result, err = leftInteger.Mod(rightInteger)
+		} else {
+			result, err = leftInteger.IntDiv(rightInteger)
+		}
+	case leftIsInteger && rightIsInteger && expression.Operator.Type != token.Div:
+		switch expression.Operator.Type {
+		case token.Add:
+			result, err = leftInteger.Add(rightInteger)
+		case token.Sub:
+			result, err = leftInteger.Sub(rightInteger)
+		case token.Mul:
+			result, err = leftInteger.Mul(rightInteger)
+		default:
+			return nil, newRuntimeError(expression, "unknown operator type %s", operator)
+		}
+	default:
+		leftReal, rightReal := leftNumber.Real(), rightNumber.Real()
+		switch expression.Operator.Type {
+		case token.Add:
+			result, err = leftReal.Add(rightReal)
+		case token.Sub:
+			result, err = leftReal.Sub(rightReal)
+		case token.Mul:
+			result, err = leftReal.Mul(rightReal)
+		case token.Div:
+			result, err = leftReal.Div(rightReal)
+		default:
+			return nil, newRuntimeError(expression, "unknown operator type %s", operator)
+		}
 	}
 
-	node, err = visitor.Visit(expression.Right)
 	if err != nil {
-		return 0, err
+		return nil, newRuntimeError(expression, "%v", err)
 	}
-	right, ok := node.(int)
+	return result, nil
+}
+
+// logical evaluates AND and OR. The right operand isn't evaluated when the left operand
+// already determines the result.
+func (v *Visitor) logical(expression *ast.BinOp, left Value) (Value, error) {
+	operator := strings.ToUpper(expression.Operator.Lexeme)
+
+	leftBoolean, ok := left.(Boolean)
 	if !ok {
-		return 0, errors.New("expected right to be an integer")
+		return nil, newRuntimeError(expression, "operator %s expects BOOLEAN operands, got %s", operator, typeName(left))
 	}
 
-	switch expression.Operator.Type {
-	case token.Add:
-		return left + right, nil
-	case token.Sub:
-		return left - right, nil
-	case token.Mul:
-		return left * right, nil
-	case token.Div:
-		return left / right, nil
+	if expression.Operator.Type == token.And && !leftBoolean {
+		return Boolean(false), nil
+	}
+	if expression.Operator.Type == token.Or && leftBoolean {
+		return Boolean(true), nil
+	}
+
+	right, err := v.evaluate(expression.Right)
+	if err != nil {
+		return nil, err
 	}
 
-	return 0, fmt.Errorf("unknown operator type %s", expression.Operator.Lexeme)
+	rightBoolean, ok := right.(Boolean)
+	if !ok {
+		return nil, newRuntimeError(expression, "operator %s expects BOOLEAN operands, got %s", operator, typeName(right))
+	}
+	return rightBoolean, nil
+}
+
+// compare evaluates the relational operators. Numbers are compared by value, an integer is
+// promoted to a real when compared with a real. Booleans are ordered FALSE < TRUE. Values that
+// aren't ordered, like pointers and sets, can only be compared for equality.
+func (v *Visitor) compare(expression *ast.BinOp, left Value, right Value) (Value, error) {
+	ordered, isOrdered := left.(Ordered)
+	if !isOrdered && (expression.Operator.Type == token.Equal || expression.Operator.Type == token.NotEqual) {
+		if equal, ok := Equal(left, right); ok {
+			return Boolean(equal == (expression.Operator.Type == token.Equal)), nil
+		}
+	}
+
+	var order int
+	ok := false
+	if isOrdered {
+		order, ok = ordered.Compare(right)
+	}
+	if !ok {
+		return nil, newRuntimeError(expression, "unable to compare %s with %s", typeName(left), typeName(right))
+	}
+
+	switch expression.Operator.Type {
+	case token.Equal:
+		return Boolean(order == 0), nil
+	case token.NotEqual:
+		return Boolean(order != 0), nil
+	case token.Less:
+		return Boolean(order < 0), nil
+	case token.LessEqual:
+		return Boolean(order <= 0), nil
+	case token.Greater:
+		return Boolean(order > 0), nil
+	case token.GreaterEqual:
+		return Boolean(order >= 0), nil
+	}
+	return nil, newRuntimeError(expression, "unknown operator type %s", expression.Operator.Lexeme)
 }
@@ -3,12 +3,12 @@ package visitor_test
 import (
 	"github.com/njirem95/simple-pascal/pkg/ast"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
-	"github.com/njirem95/simple-pascal/pkg/visitor"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
-func TestBinOpVisitor_Visit(t *testing.T) {
+func TestVisitor_VisitBinOp(t *testing.T) {
 	input := &ast.BinOp{
 		Left: &ast.Num{
 			Token: token.Token{
@@ -30,9 +30,55 @@ func TestBinOpVisitor_Visit(t *testing.T) {
 		},
 	}
 
-	visitor := visitor.BinOpVisitor{}
+	visitor := visitor2.Visitor{}
 	result, err := visitor.Visit(input)
 
 	assert.Nil(t, err)
-	assert.Equal(t, 20, result)
+	assert.Equal(t, visitor2.Integer(20), result)
+}
+
+func TestVisitor_VisitBinOp_Real(t *testing.T) {
+	input := &ast.BinOp{
+		Left: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: "3",
+			},
+			Lexeme: "3",
+		},
+		Operator: token.Token{
+			Type:   token.Div,
+			Lexeme: "/",
+		},
+		Right: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: "2",
+			},
+			Lexeme: "2",
+		},
+	}
+
+	visitor := visitor2.Visitor{}
+	result, err := visitor.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Real(1.5), result)
+
+	input.Operator = token.Token{
+		Type:   token.Mul,
+		Lexeme: "*",
+	}
+	input.Right = &ast.Num{
+		Token: token.Token{
+			Type:   token.Real,
+			Lexeme: "0.5",
+		},
+		Lexeme: "0.5",
+	}
+
+	result, err = visitor.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Real(1.5), result)
 }
@@ -0,0 +1,17 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitBlock allocates the declared variables before executing the compound statement.
+func (v *Visitor) VisitBlock(block *ast.Block) (interface{}, error) {
+	for _, declaration := range block.Declarations {
+		_, err := v.evaluate(declaration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return v.evaluate(block.Compound)
+}
@@ -0,0 +1,320 @@
+package visitor
+
+import (
+	"errors"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"math"
+	"strings"
+)
+
+// errOutOfRange is returned when an ordinal value is stepped past the first or the last value
+// of its type, for instance by SUCC(TRUE).
+var errOutOfRange = errors.New("ordinal value out of range")
+
+// Argument is the set of types a standard function accepts as argument. Description describes
+// the set in error messages, Types contains the names of the types.
+type Argument struct {
+	Description string
+	Types       []string
+}
+
+// Accepts reports whether the type with the name is one of the types of the set.
+func (a Argument) Accepts(name string) bool {
+	for _, accepted := range a.Types {
+		if strings.EqualFold(accepted, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// The sets of types the standard functions accept.
+var (
+	numberArgument  = Argument{"an INTEGER or REAL argument", []string{"integer", "real"}}
+	integerArgument = Argument{"an INTEGER argument", []string{"integer"}}
+	ordinalArgument = Argument{"an argument of an ordinal type", []string{"integer", "boolean", "char"}}
+)
+
+// Function is a standard function, which is provided by the interpreter instead of declared in
+// the program. Arity is the number of arguments and Argument the types the arguments can have.
+// Result is the name of the type of the result, which is the type of the argument when it's
+// empty. Compute computes the result from the value of the argument.
+type Function struct {
+	Arity    int
+	Argument Argument
+	Result   string
+	Compute  func(argument Value) (Value, error)
+}
+
+// Functions contains the standard functions. The semantic checker checks the calls of the
+// standard functions against the same table the interpreter evaluates them with.
+var Functions = map[string]Function{
+	"abs":    {1, numberArgument, "", abs},
+	"sqr":    {1, numberArgument, "", sqr},
+	"sqrt":   {1, numberArgument, "real", sqrt},
+	"sin":    {1, numberArgument, "real", realFunction(math.Sin)},
+	"cos":    {1, numberArgument, "real", realFunction(math.Cos)},
+	"arctan": {1, numberArgument, "real", realFunction(math.Atan)},
+	"exp":    {1, numberArgument, "real", realFunction(math.Exp)},
+	"ln":     {1, numberArgument, "real", ln},
+	"trunc":  {1, numberArgument, "integer", rounding(math.Trunc)},
+	"round":  {1, numberArgument, "integer", rounding(math.Round)},
+	"ord":    {1, ordinalArgument, "integer", ord},
+	"chr":    {1, integerArgument, "char", chr},
+	"succ":   {1, ordinalArgument, "", successor(1)},
+	"pred":   {1, ordinalArgument, "", successor(-1)},
+	"odd":    {1, integerArgument, "boolean", odd},
+}
+
+// isStandardProcedure reports whether the procedure is one of the standard procedures.
+func isStandardProcedure(name string) bool {
+	return isIO(name) || name == "inc" || name == "dec"
+}
+
+// builtinProcedure executes the standard procedure, which is provided by the interpreter instead
+// of declared in the program. Inc and Dec increment and decrement the ordinal variable by one, or
+// by the second argument.
+func (v *Visitor) builtinProcedure(statement *ast.ProcedureCall) error {
+	if isIO(statement.Name) {
+		return v.readWrite(statement)
+	}
+
+	arguments := statement.Arguments
+	if statement.Name != "inc" && statement.Name != "dec" {
+		return newRuntimeError(statement, "procedure %s is not declared", statement.Name)
+	}
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return newRuntimeError(statement, "%s expects 1 or 2 arguments, got %d", statement.Name, len(arguments))
+	}
+
+	variable, ok := arguments[0].(*ast.Variable)
+	if !ok {
+		return newRuntimeError(arguments[0], "expected a variable as argument of %s", statement.Name)
+	}
+
+	value, save, err := v.stepped(statement, variable)
+	if err != nil {
+		return err
+	}
+	if _, ok := value.(Ordinal); !ok {
+		return newRuntimeError(variable, "%s expects a variable of an ordinal type, got %s",
+			statement.Name, typeName(value))
+	}
+
+	amount := Integer(1)
+	if len(arguments) == 2 {
+		second, err := v.evaluate(arguments[1])
+		if err != nil {
+			return err
+		}
+
+		amount, ok = second.(Integer)
+		if !ok {
+			return newRuntimeError(arguments[1], "%s expects an INTEGER as second argument, got %s",
+				statement.Name, typeName(second))
+		}
+	}
+	if statement.Name == "dec" {
+		amount, err = amount.Negate()
+		if err != nil {
+			return newRuntimeError(statement, "%v", err)
+		}
+	}
+
+	result, err := step(value, amount)
+	if err != nil {
+		return newRuntimeError(statement, "%v", err)
+	}
+
+	return save(result)
+}
+
+// stepped returns the value of the variable that Inc or Dec steps, together with a function
+// that assigns the result to the variable. The selectors of an element or field are evaluated
+// once, before the element or field is read.
+func (v *Visitor) stepped(statement *ast.ProcedureCall, variable *ast.Variable) (Value, func(Value) error, error) {
+	selected, ok, err := v.element(variable)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		value, err := v.evaluate(variable)
+		save := func(result Value) error {
+			record, err := v.target(variable)
+			if err != nil {
+				return err
+			}
+			return v.store(statement, record, variable, result)
+		}
+		return value, save, err
+	}
+
+	value := selected.value()
+	if value == nil {
+		return nil, nil, newRuntimeError(variable, "%s %s is used before it is assigned a value", selected.kind(),
+			selected.Name)
+	}
+	save := func(result Value) error {
+		return v.storeElement(statement, selected, result)
+	}
+	return value, save, nil
+}
+
+// builtinFunction evaluates the argument of the standard function and returns its result.
+func (v *Visitor) builtinFunction(node ast.Node, name string, arguments []ast.Expr) (Value, error) {
+	function, ok := Functions[name]
+	if !ok {
+		return nil, newRuntimeError(node, "function %s is not declared", name)
+	}
+	if len(arguments) != function.Arity {
+		return nil, newRuntimeError(node, "%s expects %s, got %d", name, argumentCount(function.Arity),
+			len(arguments))
+	}
+
+	argument, err := v.evaluate(arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	if !function.Argument.Accepts(typeName(argument)) {
+		return nil, newRuntimeError(arguments[0], "%s expects %s, got %s", name, function.Argument.Description,
+			typeName(argument))
+	}
+
+	result, err := function.Compute(argument)
+	if err != nil {
+		return nil, newRuntimeError(node, "%v", err)
+	}
+	return result, nil
+}
+
+func abs(argument Value) (Value, error) {
+	switch number := argument.(type) {
+	case Integer:
+		if number < 0 {
+			return number.Negate()
+		}
+		return number, nil
+	case Real:
+		return Real(math.Abs(float64(number))), nil
+	}
+	return nil, nil
+}
+
+func sqr(argument Value) (Value, error) {
+	switch number := argument.(type) {
+	case Integer:
+		return number.Mul(number)
+	case Real:
+		return number.Mul(number)
+	}
+	return nil, nil
+}
+
+func sqrt(argument Value) (Value, error) {
+	number, ok := argument.(Number)
+	if !ok {
+		return nil, nil
+	}
+	if number.Real() < 0 {
+		return nil, errors.New("square root of a negative number")
+	}
+	return Real(math.Sqrt(float64(number.Real()))), nil
+}
+
+func ln(argument Value) (Value, error) {
+	number, ok := argument.(Number)
+	if !ok {
+		return nil, nil
+	}
+	if number.Real() <= 0 {
+		return nil, errors.New("logarithm of a number that isn't positive")
+	}
+	return Real(math.Log(float64(number.Real()))), nil
+}
+
+// realFunction creates a function that applies the function of the math package to a number and
+// results in a REAL.
+func realFunction(function func(float64) float64) func(argument Value) (Value, error) {
+	return func(argument Value) (Value, error) {
+		number, ok := argument.(Number)
+		if !ok {
+			return nil, nil
+		}
+		return checkReal(Real(function(float64(number.Real()))))
+	}
+}
+
+// rounding creates a function that rounds a number to an INTEGER, a REAL that doesn't fit in an
+// INTEGER results in an overflow.
+func rounding(round func(float64) float64) func(argument Value) (Value, error) {
+	return func(argument Value) (Value, error) {
+		switch number := argument.(type) {
+		case Integer:
+			return number, nil
+		case Real:
+			rounded := round(float64(number))
+			if math.IsNaN(rounded) || rounded < math.MinInt64 || rounded >= math.MaxInt64 {
+				return nil, errIntegerOverflow
+			}
+			return Integer(rounded), nil
+		}
+		return nil, nil
+	}
+}
+
+func ord(argument Value) (Value, error) {
+	if ordinal, ok := argument.(Ordinal); ok {
+		return Integer(ordinal.Ordinal()), nil
+	}
+	return nil, nil
+}
+
+func chr(argument Value) (Value, error) {
+	code, ok := argument.(Integer)
+	if !ok {
+		return nil, nil
+	}
+	if code < 0 || code > 255 {
+		return nil, errOutOfRange
+	}
+	return Char(code), nil
+}
+
+// successor creates a function that results in the ordinal value the amount of values after
+// the argument, a negative amount results in a value before the argument.
+func successor(amount Integer) func(argument Value) (Value, error) {
+	return func(argument Value) (Value, error) {
+		if _, ok := argument.(Ordinal); !ok {
+			return nil, nil
+		}
+		return step(argument, amount)
+	}
+}
+
+func odd(argument Value) (Value, error) {
+	if integer, ok := argument.(Integer); ok {
+		return Boolean(integer%2 != 0), nil
+	}
+	return nil, nil
+}
+
+// step returns the ordinal value the amount of values after the value, a negative amount
+// steps backwards. A CHAR or BOOLEAN that is stepped past the first or the last value of its
+// type is out of range.
+func step(value Value, amount Integer) (Value, error) {
+	switch ordinal := value.(type) {
+	case Integer:
+		return ordinal.Add(amount)
+	case Char:
+		if amount < -255 || amount > 255 || int(ordinal)+int(amount) < 0 || int(ordinal)+int(amount) > 255 {
+			return nil, errOutOfRange
+		}
+		return Char(int(ordinal) + int(amount)), nil
+	case Boolean:
+		if amount < -1 || amount > 1 || ordinal.Ordinal()+int(amount) < 0 || ordinal.Ordinal()+int(amount) > 1 {
+			return nil, errOutOfRange
+		}
+		return Boolean(ordinal.Ordinal()+int(amount) == 1), nil
+	}
+	return nil, errOutOfRange
+}
@@ -0,0 +1,58 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitFunctionCall_Standard(t *testing.T) {
+	minus := token.Token{Type: token.Sub, Lexeme: "-"}
+	inputs := []struct {
+		name     string
+		argument ast.Expr
+		expected visitor2.Value
+	}{
+		{"abs", &ast.UnaryOp{Operator: minus, Expression: number("3")}, visitor2.Integer(3)},
+		{"abs", &ast.UnaryOp{Operator: minus, Expression: realNumber("1.5")}, visitor2.Real(1.5)},
+		{"sqr", number("12"), visitor2.Integer(144)},
+		{"sqrt", number("16"), visitor2.Real(4)},
+		{"trunc", realNumber("2.7"), visitor2.Integer(2)},
+		{"round", realNumber("2.5"), visitor2.Integer(3)},
+		{"ord", &ast.Char{Value: 'A'}, visitor2.Integer(65)},
+		{"chr", number("97"), visitor2.Char('a')},
+		{"succ", &ast.Boolean{Value: false}, visitor2.Boolean(true)},
+		{"pred", number("0"), visitor2.Integer(-1)},
+		{"odd", number("7"), visitor2.Boolean(true)},
+	}
+
+	for _, input := range inputs {
+		visitor := visitor2.Visitor{}
+		call := &ast.FunctionCall{Name: input.name, Arguments: []ast.Expr{input.argument}}
+		result, err := visitor.Visit(call)
+		assert.Nil(t, err, input.name)
+		assert.Equal(t, input.expected, result, input.name)
+	}
+}
+
+func TestVisitor_VisitProcedureCall_Standard(t *testing.T) {
+	memory := map[string]visitor2.Value{"i": visitor2.Integer(1), "c": visitor2.Char('a')}
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+
+	_, err := visitor.Visit(&ast.ProcedureCall{Name: "inc", Arguments: []ast.Expr{&ast.Variable{Name: "i"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(2), memory["i"])
+
+	_, err = visitor.Visit(&ast.ProcedureCall{Name: "dec", Arguments: []ast.Expr{&ast.Variable{Name: "i"}, number("5")}})
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(-3), memory["i"])
+
+	_, err = visitor.Visit(&ast.ProcedureCall{Name: "inc", Arguments: []ast.Expr{&ast.Variable{Name: "c"}, number("25")}})
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Char('z'), memory["c"])
+
+	_, err = visitor.Visit(&ast.ProcedureCall{Name: "dec", Arguments: []ast.Expr{&ast.Variable{Name: "c"}, number("200")}})
+	assert.EqualError(t, err, "0:0: ordinal value out of range")
+}
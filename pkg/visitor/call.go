@@ -0,0 +1,186 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"strings"
+)
+
+// VisitProcedureCall executes the procedure. A standard procedure is executed unless the program declares a
+// procedure with the same name.
+func (v *Visitor) VisitProcedureCall(statement *ast.ProcedureCall) (interface{}, error) {
+	if declaration, _ := v.Stack.Peek().Callable(statement.Name); declaration == nil {
+		if isStandardProcedure(statement.Name) {
+			return nil, v.builtinProcedure(statement)
+		}
+		if _, ok := Functions[statement.Name]; ok {
+			return nil, newRuntimeError(statement, "the result of function %s has to be used", statement.Name)
+		}
+	}
+
+	_, err := v.call(statement, statement.Name, statement.Arguments, false)
+	return nil, err
+}
+
+// VisitFunctionCall executes the function and returns its result. A standard function is
+// executed unless the program declares a function with the same name.
+func (v *Visitor) VisitFunctionCall(expression *ast.FunctionCall) (interface{}, error) {
+	if declaration, _ := v.Stack.Peek().Callable(expression.Name); declaration == nil {
+		if _, ok := Functions[expression.Name]; ok {
+			return v.builtinFunction(expression, expression.Name, expression.Arguments)
+		}
+	}
+
+	return v.call(expression, expression.Name, expression.Arguments, true)
+}
+
+// call executes the procedure or function with the arguments. The arguments are evaluated in
+// the scope of the caller and assigned to the parameters in a new activation record, which is
+// on top of the call stack during the execution. The enclosing scope of the record is the
+// scope the procedure or function is declared in.
+func (v *Visitor) call(node ast.Node, name string, arguments []ast.Expr, function bool) (Value, error) {
+	var parameters []*ast.Param
+	var block *ast.Block
+	var result string
+
+	declaration, parent := v.Stack.Peek().Callable(name)
+	switch d := declaration.(type) {
+	case *ast.ProcedureDecl:
+		if function {
+			return nil, newRuntimeError(node, "procedure %s doesn't return a value", name)
+		}
+		parameters, block = d.Parameters, d.Block
+	case *ast.FunctionDecl:
+		if !function {
+			return nil, newRuntimeError(node, "the result of function %s has to be used", name)
+		}
+		parameters, block = d.Parameters, d.Block
+		spec, err := resolve(d.ReturnType, parent)
+		if err != nil {
+			return nil, err
+		}
+		result = spec.Name
+	default:
+		if function {
+			return nil, newRuntimeError(node, "function %s is not declared", name)
+		}
+		return nil, newRuntimeError(node, "procedure %s is not declared", name)
+	}
+
+	if len(arguments) != len(parameters) {
+		return nil, newRuntimeError(node, "%s expects %s, got %d", name, argumentCount(len(parameters)),
+			len(arguments))
+	}
+	if len(v.Stack.Records) >= maxDepth {
+		return nil, newRuntimeError(node, "stack overflow in call to %s", name)
+	}
+
+	record := NewActivationRecord(name, parent)
+	for index, parameter := range parameters {
+		name := parameter.Variable.Name
+		if _, ok := record.Types[name]; ok {
+			return nil, newRuntimeError(parameter, "parameter %s is declared more than once", name)
+		}
+		// The type of the parameter is resolved in the scope the procedure or function is
+		// declared in.
+		spec, err := resolve(parameter.Type, parent)
+		if err != nil {
+			return nil, err
+		}
+		record.Types[name] = spec.Name
+		record.ReadOnly[name] = parameter.Constant
+
+		if parameter.Reference {
+			reference, err := v.reference(arguments[index], parameter, spec.Name)
+			if err != nil {
+				return nil, err
+			}
+			record.References[name] = reference
+			continue
+		}
+
+		value, err := v.evaluate(arguments[index])
+		if err != nil {
+			return nil, err
+		}
+
+		converted, ok := convert(value, spec.Name)
+		if !ok {
+			return nil, newRuntimeError(arguments[index], "unable to pass a value of type %s as %s parameter %s",
+				typeName(value), strings.ToUpper(spec.Name), name)
+		}
+		record.Memory[name] = converted
+	}
+
+	if function {
+		record.Function = name
+		record.Types[name] = result
+	}
+
+	v.Stack.Push(record)
+	defer v.Stack.Pop()
+
+	_, err := v.evaluate(block)
+	if err != nil {
+		return nil, err
+	}
+	if !function {
+		return nil, nil
+	}
+
+	value, ok := record.Memory[name]
+	if !ok {
+		return nil, newRuntimeError(node, "function %s didn't assign a result", name)
+	}
+	return value, nil
+}
+
+// reference resolves the variable that is passed as argument for the VAR parameter. The argument
+// has to be a variable, an element of an array or a field of the same type as the parameter, widening
+// isn't possible since the procedure or function can assign the variable. The indexes of an
+// element are evaluated once, when the procedure or function is called.
+func (v *Visitor) reference(argument ast.Expr, parameter *ast.Param, expected string) (Reference, error) {
+	variable, ok := argument.(*ast.Variable)
+	if !ok {
+		return Reference{}, newRuntimeError(argument, "expected a variable as argument for VAR parameter %s",
+			parameter.Variable.Name)
+	}
+
+	selected, ok, err := v.element(variable)
+	if err != nil {
+		return Reference{}, err
+	}
+	if ok {
+		if declared := selected.declared(); declared != "" && declared != expected {
+			return Reference{}, newRuntimeError(argument, "unable to pass %s %s %s as %s VAR parameter %s",
+				strings.ToUpper(declared), selected.kind(), selected.Name, strings.ToUpper(expected),
+				parameter.Variable.Name)
+		}
+		return Reference{Name: selected.Name, Array: selected.Array, Index: selected.Index,
+			Owner: selected.Record, Field: selected.Field}, nil
+	}
+
+	record := v.Stack.Peek().Resolve(variable.Name)
+	if record == nil {
+		if declaration, _ := v.Stack.Peek().Callable(variable.Name); declaration != nil {
+			return Reference{}, newRuntimeError(argument, "expected a variable as argument for VAR parameter %s",
+				parameter.Variable.Name)
+		}
+		record = v.Stack.Peek()
+	}
+
+	// The semantic checker rejects CONST parameters as VAR arguments as well, this guards the
+	// programs that weren't checked.
+	if record.ReadOnly[variable.Name] || record.Function == variable.Name {
+		return Reference{}, newRuntimeError(argument, "unable to pass %s as argument for VAR parameter %s",
+			variable.Name, parameter.Variable.Name)
+	}
+
+	declared, ok := record.Types[variable.Name]
+	if ok && declared != expected {
+		return Reference{}, newRuntimeError(argument, "unable to pass %s variable %s as %s VAR parameter %s",
+			strings.ToUpper(declared), variable.Name, strings.ToUpper(expected), parameter.Variable.Name)
+	}
+
+	storage, key := record.Storage(variable.Name)
+	return Reference{Record: storage, Name: key}, nil
+}
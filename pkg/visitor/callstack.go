@@ -0,0 +1,190 @@
+package visitor
+
+import (
+	"bufio"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"io"
+)
+
+// maxDepth is the maximum number of activation records on the call stack, a deeper recursion
+// results in a stack overflow.
+const maxDepth = 10000
+
+// ActivationRecord contains the variables of a single invocation of the program, a procedure
+// or a function. Parent refers to the record of the enclosing scope, which is the record of the
+// procedure or function the invoked procedure or function is declared in.
+type ActivationRecord struct {
+	Name string
+	// Memory maps the names of the variables to their current values.
+	Memory map[string]Value
+	// Types maps the names of the declared variables and parameters to the name of their type.
+	Types map[string]string
+	// Callables maps the names of the declared procedures and functions to their declaration.
+	Callables map[string]ast.Declaration
+	// Definitions maps the names declared in a TYPE section to the type specification they
+	// stand for.
+	Definitions map[string]*ast.TypeSpec
+	// References maps the names of VAR parameters to the variables passed as argument.
+	References map[string]Reference
+	// ReadOnly contains the names of the CONST parameters, which can't be assigned.
+	ReadOnly map[string]bool
+	// Function is the name of the invoked function, which holds the result of the function.
+	// Function is empty for the records of the program and of procedures.
+	Function string
+	// With contains the records of the WITH statements that are being executed, the innermost
+	// last.
+	With   []WithScope
+	Parent *ActivationRecord
+}
+
+// NewActivationRecord creates the record with empty memory.
+func NewActivationRecord(name string, parent *ActivationRecord) *ActivationRecord {
+	return &ActivationRecord{
+		Name:        name,
+		Memory:      make(map[string]Value),
+		Types:       make(map[string]string),
+		Callables:   make(map[string]ast.Declaration),
+		Definitions: make(map[string]*ast.TypeSpec),
+		References:  make(map[string]Reference),
+		ReadOnly:    make(map[string]bool),
+		Parent:      parent,
+	}
+}
+
+// WithScope is the record of a WITH statement, of which the fields can be accessed by their
+// names. Name is the name of the variable the record belongs to, for instance p or a[1].
+type WithScope struct {
+	Record *Record
+	Name   string
+}
+
+// Reference refers to the variable with the name in the record. A reference to an element of an
+// array refers to the element with the index Index of Array instead, and a reference to a field
+// refers to the field with the name Field of Owner. Name is then the name of the element or field,
+// for instance a[1] or p.x. The index is resolved once, when the reference is created.
+type Reference struct {
+	Record *ActivationRecord
+	Name   string
+	Array  *Array
+	Index  int
+	Owner  *Record
+	Field  string
+}
+
+// element returns the element or field the reference refers to, false is returned when the
+// reference refers to a variable.
+func (r Reference) element() (element, bool) {
+	if r.Array == nil && r.Owner == nil {
+		return element{}, false
+	}
+	return element{Array: r.Array, Index: r.Index, Record: r.Owner, Field: r.Field, Name: r.Name}, true
+}
+
+// Storage returns the record and the name that hold the value of a variable in the record. The
+// value of a VAR parameter is stored in the variable it refers to, a VAR parameter that refers to
+// an element is accessed through the element instead.
+func (a *ActivationRecord) Storage(name string) (*ActivationRecord, string) {
+	if reference, ok := a.References[name]; ok {
+		return reference.Record, reference.Name
+	}
+	return a, name
+}
+
+// Resolve returns the record the variable is declared or assigned in, the records of the
+// enclosing scopes are searched when the record itself doesn't contain the variable. Nil is
+// returned when the variable can't be found.
+func (a *ActivationRecord) Resolve(name string) *ActivationRecord {
+	for record := a; record != nil; record = record.Parent {
+		if _, ok := record.Types[name]; ok {
+			return record
+		}
+		if _, ok := record.Memory[name]; ok {
+			return record
+		}
+		if _, ok := record.Callables[name]; ok {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Field returns the innermost WITH statement of the record whose record has a field with the
+// name, false is returned when there is none.
+func (a *ActivationRecord) Field(name string) (WithScope, bool) {
+	for index := len(a.With) - 1; index >= 0; index-- {
+		if _, ok := a.With[index].Record.Types[name]; ok {
+			return a.With[index], true
+		}
+	}
+	return WithScope{}, false
+}
+
+// Definition returns the type specification the name declared in a TYPE section stands for, the
+// records of the enclosing scopes are searched when the record itself doesn't declare the name.
+// Nil is returned when the name isn't declared.
+func (a *ActivationRecord) Definition(name string) *ast.TypeSpec {
+	for record := a; record != nil; record = record.Parent {
+		if definition, ok := record.Definitions[name]; ok {
+			return definition
+		}
+	}
+	return nil
+}
+
+// Callable returns the declaration of the procedure or function together with the record it
+// is declared in. A variable with the same name in a nearer scope hides the declaration.
+func (a *ActivationRecord) Callable(name string) (ast.Declaration, *ActivationRecord) {
+	for record := a; record != nil; record = record.Parent {
+		if declaration, ok := record.Callables[name]; ok {
+			return declaration, record
+		}
+		if _, ok := record.Types[name]; ok && record.Function != name {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// CallStack contains the activation records of the procedures and functions that are being
+// executed, the record of the program is at the bottom.
+type CallStack struct {
+	Records []*ActivationRecord
+	// Input is read by the standard procedures Read and ReadLn and Output is written by Write
+	// and WriteLn. A stack without Input has no input and discards the output when it has no
+	// Output.
+	Input  *bufio.Reader
+	Output io.Writer
+}
+
+// Push adds the record to the top of the stack.
+func (c *CallStack) Push(record *ActivationRecord) {
+	c.Records = append(c.Records, record)
+}
+
+// Pop removes the record on top of the stack.
+func (c *CallStack) Pop() {
+	c.Records = c.Records[:len(c.Records)-1]
+}
+
+// Peek returns the record on top of the stack.
+func (c *CallStack) Peek() *ActivationRecord {
+	return c.Records[len(c.Records)-1]
+}
+
+// callStack returns the stack when it exists, otherwise a new stack is created with a record
+// of the global memory. A visitor that is used on its own doesn't have a stack until it
+// evaluates its first node.
+func callStack(stack *CallStack, memory map[string]Value, types map[string]string) *CallStack {
+	if stack != nil && len(stack.Records) > 0 {
+		return stack
+	}
+
+	record := NewActivationRecord("", nil)
+	if memory != nil {
+		record.Memory = memory
+	}
+	if types != nil {
+		record.Types = types
+	}
+	return &CallStack{Records: []*ActivationRecord{record}}
+}
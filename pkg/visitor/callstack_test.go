@@ -0,0 +1,77 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestActivationRecord_Resolve(t *testing.T) {
+	global := visitor.NewActivationRecord("program", nil)
+	global.Types["x"] = "integer"
+	global.Memory["y"] = visitor.Integer(1)
+	global.Callables["p"] = &ast.ProcedureDecl{Name: "p"}
+
+	local := visitor.NewActivationRecord("p", global)
+	local.Types["y"] = "real"
+
+	assert.Equal(t, global, local.Resolve("x"))
+	assert.Equal(t, local, local.Resolve("y"))
+	assert.Nil(t, local.Resolve("p"))
+	assert.Nil(t, local.Resolve("z"))
+
+	declaration, record := local.Callable("p")
+	assert.Equal(t, global.Callables["p"], declaration)
+	assert.Equal(t, global, record)
+
+	local.Types["p"] = "integer"
+	declaration, record = local.Callable("p")
+	assert.Nil(t, declaration)
+	assert.Nil(t, record)
+}
+
+func TestActivationRecord_Definition(t *testing.T) {
+	global := visitor.NewActivationRecord("program", nil)
+	global.Definitions["point"] = &ast.TypeSpec{Name: "record"}
+
+	local := visitor.NewActivationRecord("p", global)
+	local.Definitions["n"] = &ast.TypeSpec{Name: "integer"}
+
+	assert.Equal(t, global.Definitions["point"], local.Definition("point"))
+	assert.Equal(t, local.Definitions["n"], local.Definition("n"))
+	assert.Nil(t, global.Definition("n"))
+}
+
+func TestActivationRecord_Field(t *testing.T) {
+	outer := &visitor.Record{Fields: map[string]visitor.Value{}, Types: map[string]string{"x": "integer", "y": "integer"}}
+	inner := &visitor.Record{Fields: map[string]visitor.Value{}, Types: map[string]string{"y": "real"}}
+
+	record := visitor.NewActivationRecord("program", nil)
+	record.With = []visitor.WithScope{{Record: outer, Name: "p"}, {Record: inner, Name: "q"}}
+
+	scope, ok := record.Field("x")
+	assert.True(t, ok)
+	assert.Equal(t, "p", scope.Name)
+
+	scope, ok = record.Field("y")
+	assert.True(t, ok)
+	assert.Equal(t, "q", scope.Name)
+
+	_, ok = record.Field("z")
+	assert.False(t, ok)
+}
+
+func TestCallStack(t *testing.T) {
+	stack := visitor.CallStack{}
+	global := visitor.NewActivationRecord("program", nil)
+	local := visitor.NewActivationRecord("p", global)
+
+	stack.Push(global)
+	stack.Push(local)
+	assert.Equal(t, local, stack.Peek())
+
+	stack.Pop()
+	assert.Equal(t, global, stack.Peek())
+	assert.Len(t, stack.Records, 1)
+}
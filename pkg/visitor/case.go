@@ -0,0 +1,61 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitCase executes the statement of the branch with a label that matches the value of the
+// expression, or the ELSE part when none of the labels match. The labels are checked in order
+// until one matches.
+func (v *Visitor) VisitCase(statement *ast.Case) (interface{}, error) {
+	value, err := v.evaluate(statement.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	number, ok := value.(Ordinal)
+	if !ok {
+		return nil, newRuntimeError(statement.Expression, "expected the expression of CASE to be of an ordinal type, got %s",
+			typeName(value))
+	}
+
+	for _, branch := range statement.Branches {
+		for _, label := range branch.Labels {
+			matched, err := v.matches(label, number.Ordinal())
+			if err != nil {
+				return nil, err
+			}
+
+			if matched {
+				_, err = v.evaluate(branch.Statement)
+				return nil, err
+			}
+		}
+	}
+
+	if statement.Else == nil {
+		return nil, newRuntimeError(statement.Expression, "none of the CASE labels match the value %s", Format(value))
+	}
+	return nil, v.statements(statement.Else)
+}
+
+// matches evaluates the bounds of the label and reports whether the ordinal number is in its
+// range. The semantic checker makes sure the bounds are constants of the type of the expression.
+func (v *Visitor) matches(label *ast.CaseLabel, number int) (bool, error) {
+	low, err := v.evaluate(label.Low)
+	if err != nil {
+		return false, err
+	}
+
+	high := low
+	if label.High != nil {
+		high, err = v.evaluate(label.High)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	first, ok := low.(Ordinal)
+	last, isOrdinal := high.(Ordinal)
+	return ok && isOrdinal && first.Ordinal() <= number && number <= last.Ordinal(), nil
+}
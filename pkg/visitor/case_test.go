@@ -0,0 +1,56 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// number creates the integer literal.
+func number(value string) *ast.Num {
+	return &ast.Num{
+		Token: token.Token{
+			Type:   token.Int,
+			Lexeme: value,
+		},
+		Lexeme: value,
+	}
+}
+
+func TestVisitor_VisitCase(t *testing.T) {
+	input := &ast.Case{
+		Expression: number("4"),
+		Branches: []*ast.CaseBranch{
+			{
+				Labels: []*ast.CaseLabel{
+					{Low: number("1")},
+					{Low: number("2")},
+				},
+				Statement: assign("x", "1"),
+			},
+			{
+				Labels: []*ast.CaseLabel{
+					{Low: number("3"), High: number("5")},
+				},
+				Statement: assign("x", "2"),
+			},
+		},
+	}
+
+	memory := make(map[string]visitor2.Value)
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(2), memory["x"])
+
+	input.Expression = number("6")
+	_, err = visitor.Visit(input)
+	assert.EqualError(t, err, "0:0: none of the CASE labels match the value 6")
+
+	input.Else = []ast.Statement{assign("x", "3")}
+	_, err = visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(3), memory["x"])
+}
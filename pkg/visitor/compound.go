@@ -0,0 +1,22 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitCompound executes every statement of the compound statement in order.
+func (v *Visitor) VisitCompound(compound *ast.Compound) (interface{}, error) {
+	return nil, v.statements(compound.Statements)
+}
+
+// statements executes the statements in order.
+func (v *Visitor) statements(statements []ast.Statement) error {
+	for _, statement := range statements {
+		_, err := v.evaluate(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitCompound(t *testing.T) {
+	input := []ast.Statement{
+		&ast.Assign{
+			Left: &ast.Variable{
+				Name: "x",
+				Token: token.Token{
+					Type:   token.Identifier,
+					Lexeme: "x",
+				},
+			},
+			Operator: token.Token{
+				Type:   token.Assign,
+				Lexeme: ":=",
+			},
+			Right: &ast.Num{
+				Token: token.Token{
+					Type:   token.Int,
+					Lexeme: "4",
+				},
+				Lexeme: "4",
+			},
+		},
+		&ast.Compound{
+			Statements: []ast.Statement{
+				&ast.Assign{
+					Left: &ast.Variable{
+						Name: "y",
+						Token: token.Token{
+							Type:   token.Identifier,
+							Lexeme: "y",
+						},
+					},
+					Operator: token.Token{
+						Type:   token.Assign,
+						Lexeme: ":=",
+					},
+					Right: &ast.Variable{
+						Name: "x",
+						Token: token.Token{
+							Type:   token.Identifier,
+							Lexeme: "x",
+						},
+					},
+				},
+			},
+		},
+		&ast.Empty{},
+	}
+
+	memory := make(map[string]visitor2.Value)
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(&ast.Compound{Statements: input})
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(4), memory["x"])
+	assert.Equal(t, visitor2.Integer(4), memory["y"])
+}
@@ -0,0 +1,34 @@
+package visitor
+
+import (
+	"fmt"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+)
+
+// RuntimeError is returned when the execution of the program fails, for instance on a division
+// by zero, an integer overflow or the use of a variable that wasn't assigned a value. The span
+// refers to the expression or statement that caused the error.
+type RuntimeError struct {
+	Message string
+	Span    token.Span
+}
+
+func newRuntimeError(node ast.Node, format string, args ...interface{}) *RuntimeError {
+	return &RuntimeError{
+		Message: fmt.Sprintf(format, args...),
+		Span:    ast.SpanOf(node),
+	}
+}
+
+func (e *RuntimeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Span.Start, e.Message)
+}
+
+// argumentCount formats the number of arguments, for instance 1 argument or 2 arguments.
+func argumentCount(count int) string {
+	if count == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", count)
+}
@@ -0,0 +1,43 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_Interpret_RuntimeError(t *testing.T) {
+	span := token.Span{
+		Start: token.Position{File: "main.pas", Offset: 6, Line: 2, Column: 1},
+		End:   token.Position{File: "main.pas", Offset: 9, Line: 2, Column: 4},
+	}
+
+	// A program without a block can't be the result of the parser, the interpreter must
+	// return an error instead of panicking.
+	program := &ast.Program{
+		Span: span,
+	}
+
+	interpreter := visitor.New()
+	err := interpreter.Interpret(program)
+
+	runtimeError, ok := err.(*visitor.RuntimeError)
+	assert.True(t, ok)
+	assert.Equal(t, span, runtimeError.Span)
+	assert.Contains(t, runtimeError.Error(), "main.pas:2:1: ")
+}
+
+// declaration is a node of which the method results in a declaration instead of a value.
+type declaration struct{}
+
+func (d *declaration) Accept(visitor ast.Visitor) (interface{}, error) {
+	return &ast.Block{}, nil
+}
+
+func TestVisitor_Visit_NotAValue(t *testing.T) {
+	interpreter := visitor.New()
+	_, err := interpreter.Visit(&declaration{})
+	assert.EqualError(t, err, "0:0: expected the node to result in a value, got *ast.Block")
+}
@@ -0,0 +1,79 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+)
+
+// VisitFor executes the body once for every value of the control variable. Both bounds are
+// evaluated once, before the first iteration. The control variable is undefined once the loop
+// has finished.
+func (v *Visitor) VisitFor(statement *ast.For) (interface{}, error) {
+	name := statement.Variable.Name
+	if _, ok := v.Stack.Peek().Field(name); ok {
+		return nil, newRuntimeError(statement.Variable, "unable to use field %s as control variable", name)
+	}
+	record := v.Stack.Peek().Resolve(name)
+	if record == nil {
+		record = v.Stack.Peek()
+	}
+
+	first, err := v.bound(statement.Start)
+	if err != nil {
+		return nil, err
+	}
+	last, err := v.bound(statement.End)
+	if err != nil {
+		return nil, err
+	}
+
+	step := 1
+	if statement.Direction.Type == token.Downto {
+		step = -1
+	}
+
+	// The loop stops at the last value instead of stepping past it, stepping past the last
+	// value overflows when it is the largest or smallest integer.
+	// A VAR parameter that refers to an element or field counts in the element or field.
+	storage, key := record.Storage(name)
+	target, isElement := record.References[name].element()
+	if (step > 0 && first <= last) || (step < 0 && first >= last) {
+		for value := first; ; value += step {
+			if isElement {
+				target.set(Integer(value))
+			} else {
+				storage.Memory[key] = Integer(value)
+			}
+
+			_, err := v.evaluate(statement.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			if value == last {
+				break
+			}
+		}
+	}
+
+	if isElement {
+		target.set(nil)
+	} else {
+		delete(storage.Memory, key)
+	}
+	return nil, nil
+}
+
+// bound evaluates one of the bounds of the loop, which has to result in an integer.
+func (v *Visitor) bound(expression ast.Expr) (int, error) {
+	value, err := v.evaluate(expression)
+	if err != nil {
+		return 0, err
+	}
+
+	integer, ok := value.(Integer)
+	if !ok {
+		return 0, newRuntimeError(expression, "expected the bounds of FOR to be INTEGER, got %s", typeName(value))
+	}
+	return int(integer), nil
+}
@@ -0,0 +1,78 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitFor(t *testing.T) {
+	input := &ast.For{
+		Variable: &ast.Variable{
+			Name: "i",
+			Token: token.Token{
+				Type:   token.Identifier,
+				Lexeme: "i",
+			},
+		},
+		Start: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: "1",
+			},
+			Lexeme: "1",
+		},
+		Direction: token.Token{
+			Type:   token.To,
+			Lexeme: "to",
+		},
+		End: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: "3",
+			},
+			Lexeme: "3",
+		},
+		Body: &ast.Assign{
+			Left: &ast.Variable{
+				Name: "x",
+				Token: token.Token{
+					Type:   token.Identifier,
+					Lexeme: "x",
+				},
+			},
+			Operator: token.Token{
+				Type:   token.Assign,
+				Lexeme: ":=",
+			},
+			Right: &ast.Variable{
+				Name: "i",
+				Token: token.Token{
+					Type:   token.Identifier,
+					Lexeme: "i",
+				},
+			},
+		},
+	}
+
+	memory := make(map[string]visitor2.Value)
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(3), memory["x"])
+
+	_, ok := memory["i"]
+	assert.False(t, ok)
+
+	input.Direction = token.Token{
+		Type:   token.Downto,
+		Lexeme: "downto",
+	}
+	memory["x"] = visitor2.Integer(0)
+
+	_, err = visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(0), memory["x"])
+}
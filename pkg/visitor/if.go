@@ -0,0 +1,26 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitIf evaluates the condition and executes the matching branch. The condition has to
+// result in a boolean.
+func (v *Visitor) VisitIf(statement *ast.If) (interface{}, error) {
+	value, err := v.evaluate(statement.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, ok := value.(Boolean)
+	if !ok {
+		return nil, newRuntimeError(statement.Condition, "expected the condition of IF to be a BOOLEAN, got %s", typeName(value))
+	}
+
+	if condition {
+		_, err = v.evaluate(statement.Then)
+	} else if statement.Else != nil {
+		_, err = v.evaluate(statement.Else)
+	}
+	return nil, err
+}
@@ -0,0 +1,86 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// assign creates the statement that assigns the integer to the variable.
+func assign(name string, value string) *ast.Assign {
+	return &ast.Assign{
+		Left: &ast.Variable{
+			Name: name,
+			Token: token.Token{
+				Type:   token.Identifier,
+				Lexeme: name,
+			},
+		},
+		Operator: token.Token{
+			Type:   token.Assign,
+			Lexeme: ":=",
+		},
+		Right: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: value,
+			},
+			Lexeme: value,
+		},
+	}
+}
+
+func TestVisitor_VisitIf(t *testing.T) {
+	input := &ast.If{
+		Condition: &ast.Boolean{
+			Token: token.Token{
+				Type:   token.False,
+				Lexeme: "false",
+			},
+			Value: false,
+		},
+		Then: assign("x", "1"),
+		Else: assign("x", "2"),
+	}
+
+	memory := make(map[string]visitor2.Value)
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(2), memory["x"])
+
+	input.Condition = &ast.Boolean{
+		Token: token.Token{
+			Type:   token.True,
+			Lexeme: "true",
+		},
+		Value: true,
+	}
+
+	_, err = visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(1), memory["x"])
+}
+
+func TestVisitor_VisitIf_Condition(t *testing.T) {
+	input := &ast.If{
+		Condition: &ast.Num{
+			Token: token.Token{
+				Type:   token.Int,
+				Lexeme: "1",
+			},
+			Lexeme: "1",
+		},
+		Then: assign("x", "1"),
+	}
+
+	memory := make(map[string]visitor2.Value)
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(input)
+	assert.EqualError(t, err, "0:0: expected the condition of IF to be a BOOLEAN, got INTEGER")
+
+	_, ok := memory["x"]
+	assert.False(t, ok)
+}
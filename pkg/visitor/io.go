@@ -0,0 +1,300 @@
+package visitor
+
+import (
+	"bufio"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// isIO reports whether the procedure is one of the standard procedures Read, ReadLn, Write and
+// WriteLn.
+func isIO(name string) bool {
+	switch name {
+	case "read", "readln", "write", "writeln":
+		return true
+	}
+	return false
+}
+
+// readWrite executes the standard procedures Read, ReadLn, Write and WriteLn. Read and ReadLn
+// read from the input of the call stack, Write and WriteLn write to its output.
+func (v *Visitor) readWrite(statement *ast.ProcedureCall) error {
+	switch statement.Name {
+	case "write", "writeln":
+		return v.write(statement)
+	case "read", "readln":
+		return v.read(statement)
+	}
+	return newRuntimeError(statement, "procedure %s is not declared", statement.Name)
+}
+
+// write writes the arguments to the output, WriteLn ends the line afterwards. Every argument is
+// written as soon as it is evaluated.
+func (v *Visitor) write(statement *ast.ProcedureCall) error {
+	for _, argument := range statement.Arguments {
+		field, err := v.field(argument)
+		if err != nil {
+			return err
+		}
+		if err := v.output(statement, field); err != nil {
+			return err
+		}
+	}
+
+	if statement.Name == "writeln" {
+		return v.output(statement, "\n")
+	}
+	return nil
+}
+
+// output writes the text to the output of the call stack.
+func (v *Visitor) output(statement *ast.ProcedureCall, text string) error {
+	if v.Stack.Output == nil {
+		return nil
+	}
+
+	if _, err := io.WriteString(v.Stack.Output, text); err != nil {
+		return newRuntimeError(statement, "unable to write the output: %v", err)
+	}
+	return nil
+}
+
+// field evaluates the argument and formats its value. The value is right-aligned in a field of
+// the width of a write parameter, but is never truncated. A REAL value is written in
+// scientific notation with as many decimals as fit in the field, or in fixed-point notation
+// when the number of decimals is given.
+func (v *Visitor) field(argument ast.Expr) (string, error) {
+	expression := argument
+	param, formatted := argument.(*ast.WriteParam)
+	if formatted {
+		expression = param.Expression
+	}
+
+	value, err := v.evaluate(expression)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.(type) {
+	case Integer, Real, Boolean, Char, String:
+	default:
+		return "", newRuntimeError(expression, "unable to write a value of type %s", typeName(value))
+	}
+	if !formatted {
+		return value.String(), nil
+	}
+
+	width, err := v.size(param.Width, "field width")
+	if err != nil {
+		return "", err
+	}
+
+	text := value.String()
+	real, isReal := value.(Real)
+	if param.Precision != nil {
+		if !isReal {
+			return "", newRuntimeError(param.Precision,
+				"only REAL values can be written with a number of decimals, got %s", typeName(value))
+		}
+
+		decimals, err := v.size(param.Precision, "number of decimals")
+		if err != nil {
+			return "", err
+		}
+		text = strconv.FormatFloat(float64(real), 'f', decimals, 64)
+	} else if isReal {
+		decimals := width - 8
+		if decimals < 1 {
+			decimals = 1
+		}
+		text = real.scientific(decimals)
+	}
+
+	if len(text) < width {
+		text = strings.Repeat(" ", width-len(text)) + text
+	}
+	return text, nil
+}
+
+// size evaluates the field width or the number of decimals of a write parameter, which has to
+// be an INTEGER that isn't negative.
+func (v *Visitor) size(expression ast.Expr, name string) (int, error) {
+	value, err := v.evaluate(expression)
+	if err != nil {
+		return 0, err
+	}
+
+	integer, ok := value.(Integer)
+	if !ok {
+		return 0, newRuntimeError(expression, "expected the %s to be an INTEGER, got %s", name, typeName(value))
+	}
+	if integer < 0 {
+		return 0, newRuntimeError(expression, "expected the %s to be at least 0, got %d", name, integer)
+	}
+	return int(integer), nil
+}
+
+// read reads a value for every argument from the input, ReadLn skips the remainder of the line
+// afterwards. The declared type of the variable or of the elements of the array determines how
+// the input is read: an INTEGER or a REAL is read from the next word, a CHAR is the next
+// character and a STRING is the remainder of the line.
+func (v *Visitor) read(statement *ast.ProcedureCall) error {
+	input := v.Stack.Input
+	if input == nil {
+		input = bufio.NewReader(strings.NewReader(""))
+	}
+
+	for _, argument := range statement.Arguments {
+		variable, ok := argument.(*ast.Variable)
+		if !ok {
+			return newRuntimeError(argument, "expected a variable as argument of %s", statement.Name)
+		}
+
+		// The selectors are evaluated once, before the element or field is read.
+		element, ok, err := v.element(variable)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			record, err := v.target(variable)
+			if err != nil {
+				return err
+			}
+			value, err := v.scan(input, variable, record.Types[variable.Name])
+			if err != nil {
+				return err
+			}
+			if err := v.store(argument, record, variable, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := v.scan(input, variable, element.declared())
+		if err != nil {
+			return err
+		}
+		if err := v.storeElement(argument, element, value); err != nil {
+			return err
+		}
+	}
+
+	if statement.Name == "readln" {
+		for {
+			character, err := input.ReadByte()
+			if err == io.EOF || character == '\n' {
+				break
+			}
+			if err != nil {
+				return newRuntimeError(statement, "unable to read the input: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// scan reads a value of the declared type from the input.
+func (v *Visitor) scan(input *bufio.Reader, variable *ast.Variable, declared string) (Value, error) {
+	switch declared {
+	case "integer":
+		word, err := readWord(input)
+		if err != nil {
+			return nil, inputError(variable, err)
+		}
+
+		number, err := strconv.ParseInt(word, 10, 0)
+		if err != nil {
+			return nil, newRuntimeError(variable, "unable to read %s, expected an INTEGER, got %q", variable.Name, word)
+		}
+		return Integer(number), nil
+	case "real":
+		word, err := readWord(input)
+		if err != nil {
+			return nil, inputError(variable, err)
+		}
+
+		number, err := strconv.ParseFloat(word, 64)
+		if err != nil || !isNumber(word) {
+			return nil, newRuntimeError(variable, "unable to read %s, expected a REAL, got %q", variable.Name, word)
+		}
+		return Real(number), nil
+	case "char":
+		character, err := input.ReadByte()
+		if err != nil {
+			return nil, inputError(variable, err)
+		}
+
+		// The end of a line is read as a space.
+		if character == '\r' {
+			if next, err := input.Peek(1); err == nil && next[0] == '\n' {
+				input.ReadByte()
+			}
+			return Char(' '), nil
+		}
+		if character == '\n' {
+			return Char(' '), nil
+		}
+		return Char(character), nil
+	case "string":
+		var line strings.Builder
+		for {
+			next, err := input.Peek(1)
+			if err == io.EOF || (err == nil && (next[0] == '\n' || next[0] == '\r')) {
+				return String(line.String()), nil
+			}
+			if err != nil {
+				return nil, inputError(variable, err)
+			}
+
+			line.WriteByte(next[0])
+			input.ReadByte()
+		}
+	case "":
+		return nil, newRuntimeError(variable, "unable to read %s, its type isn't declared", variable.Name)
+	}
+	return nil, newRuntimeError(variable, "unable to read %s variable %s", strings.ToUpper(declared), variable.Name)
+}
+
+// readWord skips the whitespace, including line ends, and returns the characters up to the
+// next whitespace.
+func readWord(input *bufio.Reader) (string, error) {
+	var word strings.Builder
+	for {
+		character, err := input.ReadByte()
+		if err == io.EOF && word.Len() > 0 {
+			return word.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if character == ' ' || character == '\t' || character == '\n' || character == '\r' {
+			if word.Len() > 0 {
+				return word.String(), input.UnreadByte()
+			}
+			continue
+		}
+		word.WriteByte(character)
+	}
+}
+
+// isNumber reports whether the word is written like a Pascal number, strconv accepts words
+// like "Inf" and "0x1p4" as well.
+func isNumber(word string) bool {
+	digits := strings.TrimLeft(word, "+-")
+	if digits == "" || digits[0] < '0' || digits[0] > '9' {
+		return false
+	}
+	return strings.Trim(digits, "0123456789.eE+-") == ""
+}
+
+// inputError creates the error of a failed read of the variable, reading past the end of the
+// input is reported as such.
+func inputError(variable *ast.Variable, err error) error {
+	if err == io.EOF {
+		return newRuntimeError(variable, "unexpected end of input while reading %s", variable.Name)
+	}
+	return newRuntimeError(variable, "unable to read the input: %v", err)
+}
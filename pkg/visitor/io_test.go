@@ -0,0 +1,87 @@
+package visitor_test
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func realNumber(value string) *ast.Num {
+	return &ast.Num{Token: token.Token{Type: token.Real, Lexeme: value}, Lexeme: value}
+}
+
+func TestVisitor_VisitProcedureCall_Write(t *testing.T) {
+	inputs := []struct {
+		argument ast.Expr
+		expected string
+	}{
+		{number("42"), "42"},
+		{&ast.WriteParam{Expression: number("42"), Width: number("5")}, "   42"},
+		{&ast.WriteParam{Expression: number("12345"), Width: number("2")}, "12345"},
+		{realNumber("1.5"), " 1.500000000000000E+000"},
+		{&ast.WriteParam{Expression: realNumber("1.5"), Width: number("10")}, " 1.50E+000"},
+		{&ast.WriteParam{Expression: realNumber("1.5"), Width: number("1")}, " 1.5E+000"},
+		{&ast.WriteParam{Expression: realNumber("3.14159"), Width: number("8"), Precision: number("2")}, "    3.14"},
+		{&ast.WriteParam{Expression: realNumber("2.5"), Width: number("0"), Precision: number("0")}, "2"},
+		{&ast.Boolean{Value: true}, "TRUE"},
+		{&ast.WriteParam{Expression: &ast.Char{Value: 'a'}, Width: number("3")}, "  a"},
+		{&ast.WriteParam{Expression: &ast.String{Value: "abc"}, Width: number("4")}, " abc"},
+	}
+
+	for _, input := range inputs {
+		var output bytes.Buffer
+		stack := &visitor2.CallStack{
+			Records: []*visitor2.ActivationRecord{visitor2.NewActivationRecord("", nil)},
+			Output:  &output,
+		}
+
+		visitor := visitor2.Visitor{Stack: stack}
+		_, err := visitor.Visit(&ast.ProcedureCall{Name: "writeln", Arguments: []ast.Expr{input.argument}})
+		assert.Nil(t, err)
+		assert.Equal(t, input.expected+"\n", output.String())
+	}
+}
+
+func TestVisitor_VisitProcedureCall_Read(t *testing.T) {
+	record := visitor2.NewActivationRecord("", nil)
+	record.Types = map[string]string{"i": "integer", "r": "real", "c": "char", "s": "string"}
+	stack := &visitor2.CallStack{
+		Records: []*visitor2.ActivationRecord{record},
+		Input:   bufio.NewReader(strings.NewReader("  12\n-2.5e1 xyz\nrest of line\r\nline")),
+	}
+
+	visitor := visitor2.Visitor{Stack: stack}
+	read := func(name string, variables ...string) {
+		var arguments []ast.Expr
+		for _, variable := range variables {
+			arguments = append(arguments, &ast.Variable{Name: variable})
+		}
+		_, err := visitor.Visit(&ast.ProcedureCall{Name: name, Arguments: arguments})
+		assert.Nil(t, err)
+	}
+
+	read("read", "i", "r", "c")
+	assert.Equal(t, visitor2.Integer(12), record.Memory["i"])
+	assert.Equal(t, visitor2.Real(-25), record.Memory["r"])
+	assert.Equal(t, visitor2.Char(' '), record.Memory["c"])
+
+	read("readln", "s")
+	assert.Equal(t, visitor2.String("xyz"), record.Memory["s"])
+
+	read("read", "c")
+	assert.Equal(t, visitor2.Char('r'), record.Memory["c"])
+
+	read("readln", "s")
+	assert.Equal(t, visitor2.String("est of line"), record.Memory["s"])
+
+	read("readln", "s")
+	assert.Equal(t, visitor2.String("line"), record.Memory["s"])
+
+	_, err := visitor.Visit(&ast.ProcedureCall{Name: "read", Arguments: []ast.Expr{&ast.Variable{Name: "i"}}})
+	assert.EqualError(t, err, "0:0: unexpected end of input while reading i")
+}
@@ -2,17 +2,25 @@ package visitor
 
 import (
 	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
 	"strconv"
 )
 
-type NumVisitor struct {
-}
+// VisitNum converts the lexeme of the number to an Integer for integer literals, and to a Real
+// for real literals.
+func (v *Visitor) VisitNum(expression *ast.Num) (interface{}, error) {
+	if expression.Token.Type == token.Real {
+		number, err := strconv.ParseFloat(expression.Lexeme, 64)
+		if err != nil {
+			return nil, newRuntimeError(expression, "invalid real number %s", expression.Lexeme)
+		}
+		return Real(number), nil
+	}
 
-func (n *NumVisitor) Visit(expression *ast.Num) (int, error) {
 	number, err := strconv.Atoi(expression.Lexeme)
 	if err != nil {
-		return 0, err
+		return nil, newRuntimeError(expression, "invalid integer %s", expression.Lexeme)
 	}
 
-	return number, nil
+	return Integer(number), nil
 }
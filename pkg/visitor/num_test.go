@@ -3,12 +3,12 @@ package visitor_test
 import (
 	"github.com/njirem95/simple-pascal/pkg/ast"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
-	"github.com/njirem95/simple-pascal/pkg/visitor"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
-func TestNumVisitor_Visit(t *testing.T) {
+func TestVisitor_VisitNum(t *testing.T) {
 	input := &ast.Num{
 		Token: token.Token{
 			Type:   token.Int,
@@ -17,13 +17,18 @@ func TestNumVisitor_Visit(t *testing.T) {
 		Lexeme: "12",
 	}
 
-	visitor := visitor.NumVisitor{}
+	visitor := visitor2.Visitor{}
 	result, err := visitor.Visit(input)
 
 	assert.Nil(t, err)
-	assert.Equal(t, 12, result)
+	assert.Equal(t, visitor2.Integer(12), result)
 
 	input.Lexeme = "1.5"
 	result, err = visitor.Visit(input)
 	assert.NotNil(t, err)
+
+	input.Token.Type = token.Real
+	result, err = visitor.Visit(input)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Real(1.5), result)
 }
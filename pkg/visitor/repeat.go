@@ -0,0 +1,29 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitRepeat executes the statements until the condition results in TRUE, the statements are
+// executed at least once.
+func (v *Visitor) VisitRepeat(statement *ast.Repeat) (interface{}, error) {
+	for {
+		err := v.statements(statement.Statements)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := v.evaluate(statement.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		condition, ok := value.(Boolean)
+		if !ok {
+			return nil, newRuntimeError(statement.Condition, "expected the condition of UNTIL to be a BOOLEAN, got %s", typeName(value))
+		}
+		if condition {
+			return nil, nil
+		}
+	}
+}
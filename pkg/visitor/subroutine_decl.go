@@ -0,0 +1,29 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitProcedureDecl declares the procedure in the current scope.
+func (v *Visitor) VisitProcedureDecl(declaration *ast.ProcedureDecl) (interface{}, error) {
+	return nil, v.declare(declaration, "procedure", declaration.Name)
+}
+
+// VisitFunctionDecl declares the function in the current scope.
+func (v *Visitor) VisitFunctionDecl(declaration *ast.FunctionDecl) (interface{}, error) {
+	return nil, v.declare(declaration, "function", declaration.Name)
+}
+
+// declare declares the procedure or function in the current scope, it can be called from the
+// scope and from the scopes nested in it.
+func (v *Visitor) declare(declaration ast.Declaration, kind string, name string) error {
+	record := v.Stack.Peek()
+	_, variable := record.Types[name]
+	_, callable := record.Callables[name]
+	if variable || callable {
+		return newRuntimeError(declaration, "%s %s is declared more than once", kind, name)
+	}
+
+	record.Callables[name] = declaration
+	return nil
+}
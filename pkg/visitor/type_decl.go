@@ -0,0 +1,27 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitTypeDecl declares the name of the type in the current scope, it stands for the type of
+// the declaration in the scope and in the scopes nested in it. A name of another type is
+// resolved when the type is declared.
+func (v *Visitor) VisitTypeDecl(declaration *ast.TypeDecl) (interface{}, error) {
+	record := v.Stack.Peek()
+
+	name := declaration.Name
+	_, variable := record.Types[name]
+	_, callable := record.Callables[name]
+	_, definition := record.Definitions[name]
+	if variable || callable || definition {
+		return nil, newRuntimeError(declaration, "type %s is declared more than once", name)
+	}
+
+	spec, err := resolve(declaration.Type, record)
+	if err != nil {
+		return nil, err
+	}
+	record.Definitions[name] = spec
+	return nil, nil
+}
@@ -0,0 +1,198 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"strings"
+)
+
+// maxElements is the maximum number of elements of an ARRAY or RECORD variable, including the
+// elements and fields of the arrays and records it contains.
+const maxElements = 1 << 24
+
+// standardTypes contains the names of the standard types, which are predeclared instead of
+// reserved words.
+var standardTypes = map[string]bool{"integer": true, "real": true, "boolean": true, "char": true, "string": true}
+
+// dimension is the range of the indexes of a single dimension of an ARRAY type.
+type dimension struct {
+	low    int
+	length int
+	index  string
+}
+
+// create creates the initial value of a variable of the type together with the number of values
+// it contains, which is counted towards maxElements. A variable of a simple type has no value
+// until it is assigned. An ARRAY variable contains its elements from the start, which have no
+// value until they are assigned. The elements of an ARRAY of ARRAY types are arrays. A RECORD
+// variable contains its fields, of which the ARRAY and RECORD fields are created with it.
+func (v *Visitor) create(spec *ast.TypeSpec) (Value, int, error) {
+	spec, err := v.resolve(spec)
+	if err != nil {
+		return nil, 0, err
+	}
+	if spec.Fields != nil {
+		return v.record(spec)
+	}
+
+	array := spec
+	var dimensions []dimension
+	total := 1
+	for spec.Element != nil {
+		for _, subrange := range spec.Ranges {
+			dimension, err := v.dimension(subrange)
+			if err != nil {
+				return nil, 0, err
+			}
+			if dimension.length > maxElements/total {
+				return nil, 0, newRuntimeError(subrange, "ARRAY type is too large, it can have at most %d elements",
+					maxElements)
+			}
+
+			total *= dimension.length
+			dimensions = append(dimensions, dimension)
+		}
+
+		spec, err = v.resolve(spec.Element)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if len(dimensions) == 0 {
+		return nil, 1, nil
+	}
+
+	element, count, err := v.create(spec)
+	if err != nil {
+		return nil, 0, err
+	}
+	if count > maxElements/total {
+		return nil, 0, newRuntimeError(array, "ARRAY type is too large, it can have at most %d elements", maxElements)
+	}
+	return newArray(dimensions, element, spec.Name), total * count, nil
+}
+
+// record creates a record with the fields of the RECORD type, the fields of a simple type have
+// no value until they are assigned.
+func (v *Visitor) record(spec *ast.TypeSpec) (Value, int, error) {
+	record := &Record{Fields: make(map[string]Value), Types: make(map[string]string)}
+	total := 0
+	for _, field := range spec.Fields {
+		name := field.Variable.Name
+		if _, ok := record.Types[name]; ok {
+			return nil, 0, newRuntimeError(field, "field %s is declared more than once", name)
+		}
+
+		value, count, err := v.create(field.Type)
+		if err != nil {
+			return nil, 0, err
+		}
+		if count > maxElements-total {
+			return nil, 0, newRuntimeError(spec, "RECORD type is too large, it can have at most %d elements",
+				maxElements)
+		}
+		total += count
+
+		resolved, _ := v.resolve(field.Type)
+		record.Types[name] = resolved.Name
+		if value != nil {
+			record.Fields[name] = value
+		}
+	}
+	return record, total, nil
+}
+
+// resolve returns the type specification that a name declared in a TYPE section stands for,
+// any other type specification is returned as it is.
+func (v *Visitor) resolve(spec *ast.TypeSpec) (*ast.TypeSpec, error) {
+	return resolve(spec, v.Stack.Peek())
+}
+
+// resolve returns the type specification that the name of the type specification stands for in
+// the record or in the records of the enclosing scopes. The name of a standard type stands for
+// the standard type unless the program declares a type with the same name.
+func resolve(spec *ast.TypeSpec, record *ActivationRecord) (*ast.TypeSpec, error) {
+	if spec.Token.Type != token.Identifier {
+		return spec, nil
+	}
+
+	definition := record.Definition(spec.Name)
+	if definition == nil && standardTypes[spec.Name] {
+		return spec, nil
+	}
+	if definition == nil {
+		return nil, newRuntimeError(spec, "type %s is not declared", spec.Name)
+	}
+	return definition, nil
+}
+
+// dimension evaluates the bounds of the range of indexes, which have to be of the same ordinal
+// type and can't form an empty range.
+func (v *Visitor) dimension(subrange *ast.Subrange) (dimension, error) {
+	var bounds []Ordinal
+	for _, expression := range []ast.Expr{subrange.Low, subrange.High} {
+		value, err := v.evaluate(expression)
+		if err != nil {
+			return dimension{}, err
+		}
+
+		bound, ok := value.(Ordinal)
+		if !ok {
+			return dimension{}, newRuntimeError(expression,
+				"expected the bounds of an index range to be of an ordinal type, got %s", typeName(value))
+		}
+		bounds = append(bounds, bound)
+	}
+
+	low, high := bounds[0], bounds[1]
+	if low.Type() != high.Type() {
+		return dimension{}, newRuntimeError(subrange,
+			"expected the bounds of an index range to be of the same type, got %s and %s", low.Type(), high.Type())
+	}
+
+	length := high.Ordinal() - low.Ordinal() + 1
+	if high.Ordinal() < low.Ordinal() {
+		return dimension{}, newRuntimeError(subrange, "index range %s..%s is empty", literal(low), literal(high))
+	}
+	if length <= 0 {
+		return dimension{}, newRuntimeError(subrange, "ARRAY type is too large, it can have at most %d elements",
+			maxElements)
+	}
+	return dimension{low: low.Ordinal(), length: length, index: strings.ToLower(low.Type())}, nil
+}
+
+// newArray creates the array with the dimensions, of which every element is a copy of the
+// element. The elements of a simple type have no value yet.
+func newArray(dimensions []dimension, element Value, elementType string) *Array {
+	first := dimensions[0]
+	array := &Array{
+		Low:         first.low,
+		Elements:    make([]Value, first.length),
+		IndexType:   first.index,
+		ElementType: elementType,
+	}
+
+	if len(dimensions) > 1 {
+		array.ElementType = "array"
+		for index := range array.Elements {
+			array.Elements[index] = newArray(dimensions[1:], element, elementType)
+		}
+		return array
+	}
+
+	if element != nil {
+		for index := range array.Elements {
+			array.Elements[index] = copyValue(element)
+		}
+	}
+	return array
+}
+
+// literal formats the value the way it's written in the source code, a CHAR is quoted.
+func literal(value Value) string {
+	if character, ok := value.(Char); ok {
+		return "'" + character.String() + "'"
+	}
+	return Format(value)
+}
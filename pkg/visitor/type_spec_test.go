@@ -0,0 +1,133 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// arrayType creates the ARRAY type with the ranges of indexes and the type of the elements.
+func arrayType(element string, ranges ...*ast.Subrange) *ast.TypeSpec {
+	return &ast.TypeSpec{Name: "array", Ranges: ranges, Element: &ast.TypeSpec{Name: element}}
+}
+
+// initial declares a variable of the type in the record on top of the stack and returns the
+// initial value of the variable. The variable is removed again afterwards.
+func initial(stack *visitor2.CallStack, spec *ast.TypeSpec) (visitor2.Value, error) {
+	if stack == nil {
+		stack = &visitor2.CallStack{}
+		stack.Push(visitor2.NewActivationRecord("program", nil))
+	}
+
+	visitor := visitor2.Visitor{Stack: stack}
+	_, err := visitor.Visit(&ast.VarDecl{Variable: &ast.Variable{Name: "v"}, Type: spec})
+
+	record := stack.Peek()
+	value := record.Memory["v"]
+	delete(record.Memory, "v")
+	delete(record.Types, "v")
+	return value, err
+}
+
+func TestVisitor_VisitVarDecl_Initial(t *testing.T) {
+	value, err := initial(nil, &ast.TypeSpec{Name: "integer"})
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	value, err = initial(nil, arrayType("real", &ast.Subrange{Low: number("2"), High: number("4")}))
+	assert.Nil(t, err)
+	assert.Equal(t, &visitor2.Array{
+		Low:         2,
+		Elements:    make([]visitor2.Value, 3),
+		IndexType:   "integer",
+		ElementType: "real",
+	}, value)
+
+	// ARRAY['a'..'b', FALSE..TRUE] OF CHAR is an ARRAY of ARRAY types.
+	value, err = initial(nil, arrayType("char",
+		&ast.Subrange{Low: &ast.Char{Value: 'a'}, High: &ast.Char{Value: 'b'}},
+		&ast.Subrange{Low: &ast.Boolean{Value: false}, High: &ast.Boolean{Value: true}},
+	))
+	assert.Nil(t, err)
+
+	array := value.(*visitor2.Array)
+	assert.Equal(t, int('a'), array.Low)
+	assert.Equal(t, "array", array.ElementType)
+	assert.Len(t, array.Elements, 2)
+
+	inner := array.Elements[1].(*visitor2.Array)
+	assert.Equal(t, "boolean", inner.IndexType)
+	assert.Equal(t, "char", inner.ElementType)
+	assert.NotSame(t, array.Elements[0], inner)
+}
+
+// recordType creates the RECORD type with a field of the type for every name.
+func recordType(fields map[string]*ast.TypeSpec) *ast.TypeSpec {
+	spec := &ast.TypeSpec{Name: "record", Token: token.Token{Type: token.Record, Lexeme: "record"}}
+	for name, field := range fields {
+		spec.Fields = append(spec.Fields, &ast.VarDecl{Variable: &ast.Variable{Name: name}, Type: field})
+	}
+	return spec
+}
+
+func TestVisitor_VisitVarDecl_InitialRecord(t *testing.T) {
+	stack := &visitor2.CallStack{}
+	stack.Push(visitor2.NewActivationRecord("program", nil))
+	stack.Peek().Definitions["point"] = recordType(map[string]*ast.TypeSpec{
+		"x": {Name: "integer"},
+		"y": {Name: "integer"},
+	})
+
+	// RECORD name : STRING; corners : ARRAY[1..2] OF point END
+	value, err := initial(stack, recordType(map[string]*ast.TypeSpec{
+		"name": {Name: "string"},
+		"corners": {Name: "array", Ranges: []*ast.Subrange{{Low: number("1"), High: number("2")}},
+			Element: &ast.TypeSpec{Name: "point", Token: token.Token{Type: token.Identifier, Lexeme: "point"}}},
+	}))
+	assert.Nil(t, err)
+
+	record := value.(*visitor2.Record)
+	assert.Equal(t, map[string]string{"name": "string", "corners": "array"}, record.Types)
+	assert.NotContains(t, record.Fields, "name")
+
+	corners := record.Fields["corners"].(*visitor2.Array)
+	assert.Equal(t, "record", corners.ElementType)
+	first := corners.Elements[0].(*visitor2.Record)
+	assert.Equal(t, map[string]string{"x": "integer", "y": "integer"}, first.Types)
+	assert.NotSame(t, first, corners.Elements[1])
+
+	_, err = initial(stack, &ast.TypeSpec{Name: "line", Token: token.Token{Type: token.Identifier, Lexeme: "line"}})
+	assert.EqualError(t, err, "0:0: type line is not declared")
+}
+
+func TestVisitor_VisitVarDecl_TypeErrors(t *testing.T) {
+	inputs := []struct {
+		spec     *ast.TypeSpec
+		expected string
+	}{
+		{
+			arrayType("integer", &ast.Subrange{Low: number("5"), High: number("4")}),
+			"0:0: index range 5..4 is empty",
+		},
+		{
+			arrayType("integer", &ast.Subrange{Low: number("1"), High: &ast.Char{Value: 'z'}}),
+			"0:0: expected the bounds of an index range to be of the same type, got INTEGER and CHAR",
+		},
+		{
+			arrayType("integer", &ast.Subrange{Low: realNumber("1.5"), High: number("2")}),
+			"0:0: expected the bounds of an index range to be of an ordinal type, got REAL",
+		},
+		{
+			arrayType("integer", &ast.Subrange{Low: number("1"), High: number("100000")},
+				&ast.Subrange{Low: number("1"), High: number("1000")}),
+			"0:0: ARRAY type is too large, it can have at most 16777216 elements",
+		},
+	}
+
+	for _, input := range inputs {
+		_, err := initial(nil, input.spec)
+		assert.EqualError(t, err, input.expected)
+	}
+}
@@ -1,28 +1,45 @@
 package visitor
 
 import (
-	"errors"
 	"github.com/njirem95/simple-pascal/pkg/ast"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
 )
 
-type UnaryVisitor struct {
-}
-
-func (u *UnaryVisitor) Visit(expression *ast.UnaryOp) (int, error) {
-	visitor := Visitor{}
-
-	node, err := visitor.Visit(expression.Expression)
+func (v *Visitor) VisitUnaryOp(expression *ast.UnaryOp) (interface{}, error) {
+	node, err := v.evaluate(expression.Expression)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	switch result := node.(type) {
+	case Integer:
+		if expression.Operator.Type == token.Add {
+			return result, nil
+		} else if expression.Operator.Type == token.Sub {
+			negated, err := result.Negate()
+			if err != nil {
+				return nil, newRuntimeError(expression, "%v", err)
+			}
+			return negated, nil
+		}
+	case Real:
+		if expression.Operator.Type == token.Add {
+			return result, nil
+		} else if expression.Operator.Type == token.Sub {
+			return result.Negate(), nil
+		}
+	case Boolean:
+		if expression.Operator.Type == token.Not {
+			return result.Not(), nil
+		}
+		return nil, newRuntimeError(expression.Expression, "expected operand to be a number, got BOOLEAN")
+	default:
+		return nil, newRuntimeError(expression.Expression, "expected operand to be a number, got %s", typeName(node))
 	}
-	result := node.(int)
 
-	if expression.Operator.Type == token.Add {
-		return +result, nil
-	} else if expression.Operator.Type == token.Sub {
-		return -result, nil
+	if expression.Operator.Type == token.Not {
+		return nil, newRuntimeError(expression.Expression, "operator NOT expects a BOOLEAN operand, got %s", typeName(node))
 	}
 
-	return 0, errors.New("unable to visit node")
+	return nil, newRuntimeError(expression, "unable to visit node")
 }
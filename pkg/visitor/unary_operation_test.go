@@ -8,9 +8,9 @@ import (
 	"testing"
 )
 
-func TestUnaryVisitor_Visit(t *testing.T) {
+func TestVisitor_VisitUnaryOp(t *testing.T) {
 	// TODO better testing
-	input := -6
+	input := visitor2.Integer(-6)
 	result := &ast.UnaryOp{
 		Operator: token.Token{
 			Type:   token.Sub,
@@ -25,7 +25,7 @@ func TestUnaryVisitor_Visit(t *testing.T) {
 		},
 	}
 
-	visitor := visitor2.UnaryVisitor{}
+	visitor := visitor2.Visitor{}
 	res, err := visitor.Visit(result)
 	assert.Nil(t, err)
 	assert.Equal(t, input, res)
@@ -40,3 +40,24 @@ func TestUnaryVisitor_Visit(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, input, res)
 }
+
+func TestVisitor_VisitUnaryOp_Not(t *testing.T) {
+	result := &ast.UnaryOp{
+		Operator: token.Token{
+			Type:   token.Not,
+			Lexeme: "not",
+		},
+		Expression: &ast.Boolean{
+			Token: token.Token{
+				Type:   token.True,
+				Lexeme: "true",
+			},
+			Value: true,
+		},
+	}
+
+	visitor := visitor2.Visitor{}
+	res, err := visitor.Visit(result)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Boolean(false), res)
+}
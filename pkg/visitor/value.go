@@ -0,0 +1,669 @@
+package visitor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The errors of the arithmetic operations, the visitors report them at the position of the
+// operation.
+var (
+	errIntegerOverflow = errors.New("integer overflow")
+	errRealOverflow    = errors.New("floating point overflow")
+	errDivisionByZero  = errors.New("division by zero")
+)
+
+// Value is the value of a variable or an expression during the execution of a program.
+type Value interface {
+	// Type returns the name of the Pascal type of the value, for instance INTEGER.
+	Type() string
+	// String formats the value the way Pascal writes it.
+	String() string
+}
+
+// Number is a value of type INTEGER or REAL.
+type Number interface {
+	Value
+	// Real converts the number to a REAL.
+	Real() Real
+}
+
+// Ordinal is a value of a type whose values can be counted, for instance INTEGER or BOOLEAN.
+type Ordinal interface {
+	Value
+	// Ordinal returns the ordinal number of the value.
+	Ordinal() int
+}
+
+// Ordered is a value that can be compared with the relational operators.
+type Ordered interface {
+	Value
+	// Compare returns -1 if the value is less than other, 1 if it is greater and 0 when both
+	// are equal. False is returned when the values can't be compared.
+	Compare(other Value) (int, bool)
+}
+
+// Integer is a value of type INTEGER.
+type Integer int
+
+func (i Integer) Type() string {
+	return "INTEGER"
+}
+
+func (i Integer) String() string {
+	return strconv.Itoa(int(i))
+}
+
+func (i Integer) Real() Real {
+	return Real(i)
+}
+
+func (i Integer) Ordinal() int {
+	return int(i)
+}
+
+// Compare compares the integer with another integer, or with a real after promoting the integer
+// to a real.
+func (i Integer) Compare(other Value) (int, bool) {
+	switch o := other.(type) {
+	case Integer:
+		return compareInteger(int(i), int(o)), true
+	case Real:
+		return i.Real().Compare(o)
+	}
+	return 0, false
+}
+
+// Add adds the integers, an error is returned when the result overflows.
+func (i Integer) Add(other Integer) (Integer, error) {
+	result := i + other
+	if (other >= 0) != (result >= i) {
+		return 0, errIntegerOverflow
+	}
+	return result, nil
+}
+
+// Sub subtracts the integers, an error is returned when the result overflows.
+func (i Integer) Sub(other Integer) (Integer, error) {
+	result := i - other
+	if (other >= 0) != (result <= i) {
+		return 0, errIntegerOverflow
+	}
+	return result, nil
+}
+
+// Mul multiplies the integers, an error is returned when the result overflows.
+func (i Integer) Mul(other Integer) (Integer, error) {
+	if i == 0 || other == 0 {
+		return 0, nil
+	}
+	result := i * other
+	if i == -1 && other == math.MinInt || other == -1 && i == math.MinInt || result/other != i {
+		return 0, errIntegerOverflow
+	}
+	return result, nil
+}
+
+// IntDiv divides the integers and truncates the result towards zero.
+func (i Integer) IntDiv(other Integer) (Integer, error) {
+	if other == 0 {
+		return 0, errDivisionByZero
+	}
+	if i == math.MinInt && other == -1 {
+		return 0, errIntegerOverflow
+	}
+	return i / other, nil
+}
+
+// Mod returns the remainder of the division of the integers, which has the sign of the integer
+// that is divided.
+func (i Integer) Mod(other Integer) (Integer, error) {
+	if other == 0 {
+		return 0, errDivisionByZero
+	}
+	return i % other, nil
+}
+
+// Negate returns the integer with the opposite sign.
+func (i Integer) Negate() (Integer, error) {
+	if i == math.MinInt {
+		return 0, errIntegerOverflow
+	}
+	return -i, nil
+}
+
+// Real is a value of type REAL.
+type Real float64
+
+func (r Real) Type() string {
+	return "REAL"
+}
+
+// String writes the real in scientific notation with a mantissa of 15 decimals and a three
+// digit exponent, preceded by a space when the value is positive, for instance
+// " 1.500000000000000E+000".
+func (r Real) String() string {
+	return r.scientific(15)
+}
+
+// scientific writes the real in scientific notation with the number of decimals in the
+// mantissa, the result is 8 characters longer than the number of decimals.
+func (r Real) scientific(decimals int) string {
+	formatted := strconv.FormatFloat(float64(r), 'E', decimals, 64)
+
+	// strconv writes at least two exponent digits, Pascal writes three.
+	index := strings.IndexByte(formatted, 'E')
+	if index == -1 {
+		return formatted
+	}
+	mantissa, sign, digits := formatted[:index], formatted[index+1], formatted[index+2:]
+	for len(digits) < 3 {
+		digits = "0" + digits
+	}
+
+	formatted = fmt.Sprintf("%sE%c%s", mantissa, sign, digits)
+	if !math.Signbit(float64(r)) {
+		formatted = " " + formatted
+	}
+	return formatted
+}
+
+func (r Real) Real() Real {
+	return r
+}
+
+// Compare compares the real with another real, or with an integer after promoting the integer
+// to a real.
+func (r Real) Compare(other Value) (int, bool) {
+	number, ok := other.(Number)
+	if !ok {
+		return 0, false
+	}
+
+	switch o := number.Real(); {
+	case r < o:
+		return -1, true
+	case r > o:
+		return 1, true
+	}
+	return 0, true
+}
+
+// Add adds the reals, an error is returned when the result overflows.
+func (r Real) Add(other Real) (Real, error) {
+	return checkReal(r + other)
+}
+
+// Sub subtracts the reals, an error is returned when the result overflows.
+func (r Real) Sub(other Real) (Real, error) {
+	return checkReal(r - other)
+}
+
+// Mul multiplies the reals, an error is returned when the result overflows.
+func (r Real) Mul(other Real) (Real, error) {
+	return checkReal(r * other)
+}
+
+// Div divides the reals, an error is returned when the result overflows or other is zero.
+func (r Real) Div(other Real) (Real, error) {
+	if other == 0 {
+		return 0, errDivisionByZero
+	}
+	return checkReal(r / other)
+}
+
+// Negate returns the real with the opposite sign.
+func (r Real) Negate() Real {
+	return -r
+}
+
+// checkReal returns an error when the result of an operation isn't a finite number.
+func checkReal(result Real) (Real, error) {
+	if math.IsInf(float64(result), 0) || math.IsNaN(float64(result)) {
+		return 0, errRealOverflow
+	}
+	return result, nil
+}
+
+// Boolean is a value of type BOOLEAN, FALSE is ordered before TRUE.
+type Boolean bool
+
+func (b Boolean) Type() string {
+	return "BOOLEAN"
+}
+
+func (b Boolean) String() string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// Ordinal returns 0 for FALSE and 1 for TRUE.
+func (b Boolean) Ordinal() int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (b Boolean) Compare(other Value) (int, bool) {
+	o, ok := other.(Boolean)
+	if !ok {
+		return 0, false
+	}
+	return compareInteger(b.Ordinal(), o.Ordinal()), true
+}
+
+func (b Boolean) And(other Boolean) Boolean {
+	return b && other
+}
+
+func (b Boolean) Or(other Boolean) Boolean {
+	return b || other
+}
+
+func (b Boolean) Not() Boolean {
+	return !b
+}
+
+// Char is a value of type CHAR, the characters are ordered by their character code.
+type Char byte
+
+func (c Char) Type() string {
+	return "CHAR"
+}
+
+func (c Char) String() string {
+	return string([]byte{byte(c)})
+}
+
+func (c Char) Ordinal() int {
+	return int(c)
+}
+
+// Compare compares the character with another character, or with a string after converting
+// the character to a string.
+func (c Char) Compare(other Value) (int, bool) {
+	switch o := other.(type) {
+	case Char:
+		return compareInteger(int(c), int(o)), true
+	case String:
+		return String(c.String()).Compare(o)
+	}
+	return 0, false
+}
+
+// String is a value of type STRING, strings are ordered lexicographically.
+type String string
+
+func (s String) Type() string {
+	return "STRING"
+}
+
+func (s String) String() string {
+	return string(s)
+}
+
+// Compare compares the string with another string, or with a character after converting the
+// character to a string.
+func (s String) Compare(other Value) (int, bool) {
+	switch o := other.(type) {
+	case String:
+		return strings.Compare(string(s), string(o)), true
+	case Char:
+		return strings.Compare(string(s), o.String()), true
+	}
+	return 0, false
+}
+
+// Concat returns the string followed by other.
+func (s String) Concat(other String) String {
+	return s + other
+}
+
+// isText reports whether the value is a CHAR or a STRING.
+func isText(value Value) bool {
+	switch value.(type) {
+	case Char, String:
+		return true
+	}
+	return false
+}
+
+// toString converts a CHAR or a STRING to a STRING.
+func toString(value Value) String {
+	if character, ok := value.(Char); ok {
+		return String(character.String())
+	}
+	s, _ := value.(String)
+	return s
+}
+
+// Array is a value of an array type, the first element has the index Low. IndexType is the name
+// of the ordinal type of the indexes and ElementType is the name of the declared type of the
+// elements, both are empty when the types aren't known.
+type Array struct {
+	Low         int
+	Elements    []Value
+	IndexType   string
+	ElementType string
+}
+
+func (a *Array) Type() string {
+	return "ARRAY"
+}
+
+// String writes the elements between brackets, for instance [1, 2, 3].
+func (a *Array) String() string {
+	elements := make([]string, len(a.Elements))
+	for index, element := range a.Elements {
+		elements[index] = Format(element)
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// Index returns the element with the index, false is returned when the index is out of bounds.
+func (a *Array) Index(index int) (Value, bool) {
+	if index < a.Low || index-a.Low >= len(a.Elements) {
+		return nil, false
+	}
+	return a.Elements[index-a.Low], true
+}
+
+// Set replaces the element with the index, false is returned when the index is out of bounds.
+func (a *Array) Set(index int, value Value) bool {
+	if index < a.Low || index-a.Low >= len(a.Elements) {
+		return false
+	}
+	a.Elements[index-a.Low] = value
+	return true
+}
+
+// High returns the index of the last element.
+func (a *Array) High() int {
+	return a.Low + len(a.Elements) - 1
+}
+
+// bound returns the index with the ordinal number as a value of the type of the indexes.
+func (a *Array) bound(ordinal int) Value {
+	switch a.IndexType {
+	case "char":
+		return Char(ordinal)
+	case "boolean":
+		return Boolean(ordinal == 1)
+	}
+	return Integer(ordinal)
+}
+
+// sameType reports whether both arrays have the same indexes and elements of the same type.
+func (a *Array) sameType(other *Array) bool {
+	if a.Low != other.Low || len(a.Elements) != len(other.Elements) || a.IndexType != other.IndexType ||
+		a.ElementType != other.ElementType {
+		return false
+	}
+
+	// The elements of an ARRAY of ARRAY or RECORD types are created together with the array.
+	first, _ := a.Index(a.Low)
+	second, _ := other.Index(other.Low)
+	return sameType(first, second)
+}
+
+// Copy returns a copy of the array, assigning an array copies all of its elements.
+func (a *Array) Copy() Value {
+	elements := make([]Value, len(a.Elements))
+	for index, element := range a.Elements {
+		elements[index] = copyValue(element)
+	}
+	return &Array{Low: a.Low, Elements: elements, IndexType: a.IndexType, ElementType: a.ElementType}
+}
+
+// Record is a value of a record type, which maps the names of the fields to their values. Types
+// maps the names of the declared fields to the name of their type, a field of a simple type is
+// only contained in Fields once it is assigned.
+type Record struct {
+	Fields map[string]Value
+	Types  map[string]string
+}
+
+func (r *Record) Type() string {
+	return "RECORD"
+}
+
+// String writes the fields sorted by name, for instance (x: 1; y: 2).
+func (r *Record) String() string {
+	var names []string
+	for name := range r.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, len(names))
+	for index, name := range names {
+		fields[index] = name + ": " + Format(r.Fields[name])
+	}
+	return "(" + strings.Join(fields, "; ") + ")"
+}
+
+// Copy returns a copy of the record, assigning a record copies all of its fields.
+func (r *Record) Copy() Value {
+	fields := make(map[string]Value, len(r.Fields))
+	for name, field := range r.Fields {
+		fields[name] = copyValue(field)
+	}
+	return &Record{Fields: fields, Types: r.Types}
+}
+
+// declares reports whether the record has a field with the name.
+func (r *Record) declares(name string) bool {
+	_, ok := r.Types[name]
+	return ok
+}
+
+// sameType reports whether both records have the same fields of the same types.
+func (r *Record) sameType(other *Record) bool {
+	if len(r.Types) != len(other.Types) {
+		return false
+	}
+	for name, declared := range r.Types {
+		if otherDeclared, ok := other.Types[name]; !ok || otherDeclared != declared {
+			return false
+		}
+		if !sameType(r.Fields[name], other.Fields[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Pointer is a value of a pointer type, a pointer without a target is NIL. Pointers are equal
+// when they point to the same variable.
+type Pointer struct {
+	Target *Value
+}
+
+func (p Pointer) Type() string {
+	return "POINTER"
+}
+
+func (p Pointer) String() string {
+	if p.Target == nil {
+		return "NIL"
+	}
+	return fmt.Sprintf("^%s", Format(*p.Target))
+}
+
+// Equal reports whether the pointers point to the same variable.
+func (p Pointer) Equal(other Value) bool {
+	o, ok := other.(Pointer)
+	return ok && p.Target == o.Target
+}
+
+// Set is a value of a set type, which contains the ordinal numbers of its elements.
+type Set struct {
+	Elements map[int]bool
+}
+
+// NewSet creates the set with the ordinal numbers.
+func NewSet(elements ...int) Set {
+	set := Set{Elements: make(map[int]bool, len(elements))}
+	for _, element := range elements {
+		set.Elements[element] = true
+	}
+	return set
+}
+
+func (s Set) Type() string {
+	return "SET"
+}
+
+// String writes the ordinal numbers of the elements in ascending order, for instance [1, 3].
+func (s Set) String() string {
+	elements := make([]string, 0, len(s.Elements))
+	for _, element := range s.sorted() {
+		elements = append(elements, strconv.Itoa(element))
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// Contains reports whether the ordinal number is an element of the set.
+func (s Set) Contains(element int) bool {
+	return s.Elements[element]
+}
+
+// Union returns the elements that are in either set.
+func (s Set) Union(other Set) Set {
+	result := NewSet(s.sorted()...)
+	for element := range other.Elements {
+		result.Elements[element] = true
+	}
+	return result
+}
+
+// Intersection returns the elements that are in both sets.
+func (s Set) Intersection(other Set) Set {
+	result := NewSet()
+	for element := range s.Elements {
+		if other.Elements[element] {
+			result.Elements[element] = true
+		}
+	}
+	return result
+}
+
+// Difference returns the elements of the set that aren't in other.
+func (s Set) Difference(other Set) Set {
+	result := NewSet()
+	for element := range s.Elements {
+		if !other.Elements[element] {
+			result.Elements[element] = true
+		}
+	}
+	return result
+}
+
+// Equal reports whether the sets contain the same elements.
+func (s Set) Equal(other Value) bool {
+	o, ok := other.(Set)
+	if !ok || len(s.Elements) != len(o.Elements) {
+		return false
+	}
+	for element := range s.Elements {
+		if !o.Elements[element] {
+			return false
+		}
+	}
+	return true
+}
+
+// sorted returns the elements of the set in ascending order.
+func (s Set) sorted() []int {
+	elements := make([]int, 0, len(s.Elements))
+	for element := range s.Elements {
+		elements = append(elements, element)
+	}
+	sort.Ints(elements)
+	return elements
+}
+
+// Equal reports whether the values are equal. Ordered values are equal when they compare as
+// equal, pointers and sets define their own equality. False is returned for values that can't
+// be compared.
+func Equal(left Value, right Value) (equal bool, ok bool) {
+	if ordered, isOrdered := left.(Ordered); isOrdered {
+		order, ok := ordered.Compare(right)
+		return ok && order == 0, ok
+	}
+
+	switch l := left.(type) {
+	case Pointer:
+		_, ok := right.(Pointer)
+		return l.Equal(right), ok
+	case Set:
+		_, ok := right.(Set)
+		return l.Equal(right), ok
+	}
+	return false, false
+}
+
+// Format formats the value the way Pascal writes it, a missing value is written as undefined.
+func Format(value Value) string {
+	if value == nil {
+		return "undefined"
+	}
+	return value.String()
+}
+
+// sameType reports whether both values are arrays of the same type or records of the same type,
+// or whether neither of them is an array or a record.
+func sameType(first Value, second Value) bool {
+	switch f := first.(type) {
+	case *Array:
+		s, ok := second.(*Array)
+		return ok && f.sameType(s)
+	case *Record:
+		s, ok := second.(*Record)
+		return ok && f.sameType(s)
+	}
+
+	switch second.(type) {
+	case *Array, *Record:
+		return false
+	}
+	return true
+}
+
+// copyValue returns a copy of the value. Arrays and records are copied, the other values can't
+// be modified and are returned as they are.
+func copyValue(value Value) Value {
+	switch v := value.(type) {
+	case *Array:
+		return v.Copy()
+	case *Record:
+		return v.Copy()
+	}
+	return value
+}
+
+// typeName returns the name of the Pascal type of the value, for instance INTEGER.
+func typeName(value Value) string {
+	if value == nil {
+		return "no value"
+	}
+	return value.Type()
+}
+
+// compareInteger returns -1 if left is less than right, 1 if left is greater than right and 0 otherwise.
+func compareInteger(left int, right int) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	}
+	return 0
+}
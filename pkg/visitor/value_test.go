@@ -0,0 +1,123 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	target := visitor.Value(visitor.Integer(7))
+	inputs := map[visitor.Value]string{
+		visitor.Integer(12):              "12",
+		visitor.Integer(-3):              "-3",
+		visitor.Real(1.5):                " 1.500000000000000E+000",
+		visitor.Real(-0.00125):           "-1.250000000000000E-003",
+		visitor.Real(0):                  " 0.000000000000000E+000",
+		visitor.Real(1e100):              " 1.000000000000000E+100",
+		visitor.Real(math.Inf(1)):        "+Inf",
+		visitor.Boolean(true):            "TRUE",
+		visitor.Boolean(false):           "FALSE",
+		visitor.Char('a'):                "a",
+		visitor.String("a string"):       "a string",
+		visitor.Pointer{}:                "NIL",
+		visitor.Pointer{Target: &target}: "^7",
+		nil:                              "undefined",
+		&visitor.Array{Low: 1}:           "[]",
+		&visitor.Record{Fields: map[string]visitor.Value{}}: "()",
+	}
+
+	for input, expected := range inputs {
+		assert.Equal(t, expected, visitor.Format(input))
+	}
+
+	array := &visitor.Array{Low: 1, Elements: []visitor.Value{visitor.Integer(1), nil}}
+	assert.Equal(t, "[1, undefined]", visitor.Format(array))
+
+	record := &visitor.Record{Fields: map[string]visitor.Value{"y": visitor.Boolean(true), "x": visitor.Integer(1)}}
+	assert.Equal(t, "(x: 1; y: TRUE)", visitor.Format(record))
+
+	assert.Equal(t, "[1, 3]", visitor.Format(visitor.NewSet(3, 1)))
+}
+
+func TestInteger_Arithmetic(t *testing.T) {
+	result, err := visitor.Integer(7).Add(5)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor.Integer(12), result)
+
+	result, err = visitor.Integer(-7).IntDiv(2)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor.Integer(-3), result)
+
+	result, err = visitor.Integer(-7).Mod(3)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor.Integer(-1), result)
+
+	_, err = visitor.Integer(math.MaxInt).Add(1)
+	assert.EqualError(t, err, "integer overflow")
+	_, err = visitor.Integer(math.MinInt).Sub(1)
+	assert.EqualError(t, err, "integer overflow")
+	_, err = visitor.Integer(math.MinInt).Mul(-1)
+	assert.EqualError(t, err, "integer overflow")
+	_, err = visitor.Integer(math.MinInt).Negate()
+	assert.EqualError(t, err, "integer overflow")
+	_, err = visitor.Integer(1).Mod(0)
+	assert.EqualError(t, err, "division by zero")
+}
+
+func TestReal_Arithmetic(t *testing.T) {
+	result, err := visitor.Integer(3).Real().Div(2)
+	assert.Nil(t, err)
+	assert.Equal(t, visitor.Real(1.5), result)
+
+	_, err = visitor.Real(1).Div(0)
+	assert.EqualError(t, err, "division by zero")
+	_, err = visitor.Real(math.MaxFloat64).Mul(2)
+	assert.EqualError(t, err, "floating point overflow")
+}
+
+func TestEqual(t *testing.T) {
+	target := visitor.Value(visitor.Integer(1))
+	inputs := []struct {
+		left  visitor.Value
+		right visitor.Value
+		equal bool
+		ok    bool
+	}{
+		{visitor.Integer(2), visitor.Real(2), true, true},
+		{visitor.Real(2.5), visitor.Integer(2), false, true},
+		{visitor.Boolean(true), visitor.Integer(1), false, false},
+		{visitor.Char('a'), visitor.Char('a'), true, true},
+		{visitor.String("ab"), visitor.String("abc"), false, true},
+		{visitor.Pointer{}, visitor.Pointer{}, true, true},
+		{visitor.Pointer{Target: &target}, visitor.Pointer{}, false, true},
+		{visitor.NewSet(1, 2), visitor.NewSet(2, 1), true, true},
+		{visitor.NewSet(1, 2).Union(visitor.NewSet(3)).Difference(visitor.NewSet(1)), visitor.NewSet(2, 3), true, true},
+		{visitor.NewSet(1, 2).Intersection(visitor.NewSet(2, 3)), visitor.NewSet(2), true, true},
+		{visitor.NewSet(1), visitor.Integer(1), false, false},
+		{&visitor.Array{}, &visitor.Array{}, false, false},
+	}
+
+	for _, input := range inputs {
+		equal, ok := visitor.Equal(input.left, input.right)
+		assert.Equal(t, input.equal, equal, "%v = %v", input.left, input.right)
+		assert.Equal(t, input.ok, ok, "%v = %v", input.left, input.right)
+	}
+}
+
+func TestArray_Copy(t *testing.T) {
+	inner := &visitor.Record{Fields: map[string]visitor.Value{"x": visitor.Integer(1)}}
+	array := &visitor.Array{Low: 1, Elements: []visitor.Value{inner}}
+
+	copied := array.Copy().(*visitor.Array)
+	copied.Elements[0].(*visitor.Record).Fields["x"] = visitor.Integer(2)
+	assert.Equal(t, visitor.Integer(1), inner.Fields["x"])
+
+	element, ok := copied.Index(1)
+	assert.True(t, ok)
+	assert.Equal(t, "(x: 2)", element.String())
+
+	_, ok = copied.Index(2)
+	assert.False(t, ok)
+}
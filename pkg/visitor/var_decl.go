@@ -0,0 +1,35 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitVarDecl allocates the variable with its type in the current scope, the variable has no
+// value until it is assigned. An ARRAY variable contains its elements and a RECORD variable
+// contains its fields, which have no value until they are assigned.
+func (v *Visitor) VisitVarDecl(declaration *ast.VarDecl) (interface{}, error) {
+	record := v.Stack.Peek()
+
+	name := declaration.Variable.Name
+	_, variable := record.Types[name]
+	_, callable := record.Callables[name]
+	_, definition := record.Definitions[name]
+	if variable || callable || definition {
+		return nil, newRuntimeError(declaration, "variable %s is declared more than once", name)
+	}
+
+	value, _, err := v.create(declaration.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := v.resolve(declaration.Type)
+	if err != nil {
+		return nil, err
+	}
+	record.Types[name] = spec.Name
+	if value != nil {
+		record.Memory[name] = value
+	}
+	return nil, nil
+}
@@ -0,0 +1,54 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	"github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitVarDecl(t *testing.T) {
+	input := &ast.VarDecl{
+		Variable: &ast.Variable{
+			Name: "x",
+			Token: token.Token{
+				Type:   token.Identifier,
+				Lexeme: "x",
+			},
+		},
+		Type: &ast.TypeSpec{
+			Token: token.Token{
+				Type:   token.Identifier,
+				Lexeme: "real",
+			},
+			Name: "real",
+		},
+	}
+
+	types := make(map[string]string)
+	visitor := visitor.Visitor{GlobalTypes: types}
+	_, err := visitor.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "real", types["x"])
+
+	_, err = visitor.Visit(input)
+	assert.NotNil(t, err)
+}
+
+func TestVisitor_VisitVarDecl_Array(t *testing.T) {
+	input := &ast.VarDecl{
+		Variable: &ast.Variable{Name: "a"},
+		Type:     arrayType("integer", &ast.Subrange{Low: number("1"), High: number("3")}),
+	}
+
+	memory := make(map[string]visitor.Value)
+	types := make(map[string]string)
+	declaration := visitor.Visitor{GlobalMemory: memory, GlobalTypes: types}
+	_, err := declaration.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "array", types["a"])
+	assert.Len(t, memory["a"].(*visitor.Array).Elements, 3)
+}
@@ -0,0 +1,223 @@
+package visitor
+
+import (
+	"fmt"
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"strings"
+)
+
+// element is the element of an ARRAY with the index Index in Array, or the field with the name
+// Field of Record. Name is the name of the variable together with its selectors, for instance
+// a[1, 'b'] or p.x.
+type element struct {
+	Array  *Array
+	Index  int
+	Record *Record
+	Field  string
+	Name   string
+}
+
+// value returns the value of the element or field, nil is returned when it has no value yet.
+func (e element) value() Value {
+	if e.Record != nil {
+		return e.Record.Fields[e.Field]
+	}
+	value, _ := e.Array.Index(e.Index)
+	return value
+}
+
+// set replaces the value of the element or field.
+func (e element) set(value Value) {
+	if e.Record != nil {
+		e.Record.Fields[e.Field] = value
+		return
+	}
+	e.Array.Set(e.Index, value)
+}
+
+// declared returns the name of the declared type of the element or field.
+func (e element) declared() string {
+	if e.Record != nil {
+		return e.Record.Types[e.Field]
+	}
+	return e.Array.ElementType
+}
+
+// kind returns whether the element is an element of an array or a field of a record.
+func (e element) kind() string {
+	if e.Record != nil {
+		return "field"
+	}
+	return "element"
+}
+
+// VisitVariable returns the value of the variable from the nearest scope that contains it, or the value
+// of the element or field its selectors select. Inside a WITH statement the name of a field
+// refers to the field of its record. The name of a function refers to the function itself,
+// using it as a variable calls the function without arguments.
+func (v *Visitor) VisitVariable(expression *ast.Variable) (interface{}, error) {
+	value, _, err := v.variable(expression)
+	return value, err
+}
+
+// variable returns the value of the variable together with the name of the variable, element or
+// field it refers to.
+func (v *Visitor) variable(expression *ast.Variable) (Value, string, error) {
+	selected, ok, err := v.locate(expression, func() (Value, error) {
+		return v.lookup(expression)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		value, err := v.lookup(expression)
+		return value, expression.Name, err
+	}
+
+	result := selected.value()
+	if result == nil {
+		return nil, "", newRuntimeError(expression, "%s %s is used before it is assigned a value",
+			selected.kind(), selected.Name)
+	}
+	return result, selected.Name, nil
+}
+
+// lookup returns the value of the variable without its selectors.
+func (v *Visitor) lookup(expression *ast.Variable) (Value, error) {
+	name := expression.Name
+
+	for record := v.Stack.Peek(); record != nil; record = record.Parent {
+		if record.Function == name {
+			break
+		}
+		_, declared := record.Types[name]
+		_, assigned := record.Memory[name]
+		if declared || assigned {
+			storage, key := record.Storage(name)
+			value, ok := storage.Memory[key]
+			if !ok {
+				return nil, newRuntimeError(expression, "variable %s is used before it is assigned a value", name)
+			}
+			return value, nil
+		}
+		if _, ok := record.Callables[name]; ok {
+			break
+		}
+	}
+
+	if declaration, _ := v.Stack.Peek().Callable(name); declaration != nil {
+		return v.call(expression, name, nil, true)
+	}
+	if _, ok := Functions[name]; ok {
+		return v.builtinFunction(expression, name, nil)
+	}
+	return nil, newRuntimeError(expression, "variable %s is used before it is assigned a value", name)
+}
+
+// referenced returns the element or field that the VAR parameter with the name refers to, false
+// is returned when the name isn't a VAR parameter that refers to an element or field.
+func (v *Visitor) referenced(name string) (element, bool) {
+	record := v.Stack.Peek().Resolve(name)
+	if record == nil {
+		return element{}, false
+	}
+	return record.References[name].element()
+}
+
+// locate returns the element or field the variable selects. The selectors start from the field
+// of a WITH statement with the name of the variable, from the element or field a VAR parameter
+// with the name refers to, or otherwise from the value root returns.
+// Every index selects an element of an array and has to be within its bounds, every field has
+// to be a field of a record. False is returned when the variable is neither a field of a WITH
+// statement nor a reference to an element or field, and has no selectors.
+func (v *Visitor) locate(variable *ast.Variable, root func() (Value, error)) (element, bool, error) {
+	// The element is named after the variable or the last field, followed by the indexes that
+	// follow it. Consecutive indexes are named together, for instance a[1, 2] instead of a[1][2].
+	var indexes []string
+	kind, name := "variable", variable.Name
+	selected := func() string {
+		if len(indexes) == 0 {
+			return name
+		}
+		return fmt.Sprintf("%s[%s]", name, strings.Join(indexes, ", "))
+	}
+
+	var result element
+	var value Value
+	if scope, ok := v.Stack.Peek().Field(variable.Name); ok {
+		kind, name = "field", scope.Name+"."+variable.Name
+		result = element{Record: scope.Record, Field: variable.Name, Name: name}
+	} else if reference, ok := v.referenced(variable.Name); ok {
+		kind, name = reference.kind(), reference.Name
+		result = reference
+	} else if len(variable.Selectors) == 0 {
+		return element{}, false, nil
+	} else {
+		root, err := root()
+		if err != nil {
+			return element{}, false, err
+		}
+		value = root
+	}
+
+	for _, selector := range variable.Selectors {
+		// An element or field of a simple type without a value is described by its declared type.
+		valueType := typeName(value)
+		if result.Array != nil || result.Record != nil {
+			value = result.value()
+			valueType = typeName(value)
+			if value == nil && result.declared() != "" {
+				valueType = strings.ToUpper(result.declared())
+			}
+		}
+
+		if field, ok := selector.(*ast.Field); ok {
+			record, ok := value.(*Record)
+			if !ok || !record.declares(field.Name) {
+				owner := kind
+				if len(indexes) > 0 {
+					owner = "element"
+				}
+				return element{}, false, newRuntimeError(field, "%s %s %s has no field %s",
+					valueType, owner, selected(), field.Name)
+			}
+
+			kind, name = "field", selected()+"."+field.Name
+			indexes = nil
+			result = element{Record: record, Field: field.Name, Name: name}
+			continue
+		}
+
+		array, ok := value.(*Array)
+		if !ok && len(indexes) == 0 {
+			return element{}, false, newRuntimeError(selector, "unable to index %s %s %s", valueType, kind, name)
+		}
+		if !ok {
+			return element{}, false, newRuntimeError(selector, "too many indexes for %s %s", kind, name)
+		}
+
+		index, err := v.evaluate(selector)
+		if err != nil {
+			return element{}, false, err
+		}
+
+		ordinal, ok := index.(Ordinal)
+		if !ok && array.IndexType == "" {
+			return element{}, false, newRuntimeError(selector, "expected an index of an ordinal type, got %s",
+				typeName(index))
+		}
+		if !ok || (array.IndexType != "" && !strings.EqualFold(array.IndexType, typeName(index))) {
+			return element{}, false, newRuntimeError(selector, "expected an index of type %s, got %s",
+				strings.ToUpper(array.IndexType), typeName(index))
+		}
+
+		if _, ok := array.Index(ordinal.Ordinal()); !ok {
+			low, high := array.bound(array.Low), array.bound(array.High())
+			return element{}, false, newRuntimeError(selector, "index %s of %s is out of range %s..%s",
+				literal(index), name, literal(low), literal(high))
+		}
+		indexes = append(indexes, literal(index))
+		result = element{Array: array, Index: ordinal.Ordinal(), Name: selected()}
+	}
+	return result, true, nil
+}
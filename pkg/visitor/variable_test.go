@@ -0,0 +1,65 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitVariable(t *testing.T) {
+	input := &ast.Variable{
+		Name: "x",
+		Token: token.Token{
+			Type:   token.Identifier,
+			Lexeme: "x",
+		},
+	}
+
+	memory := map[string]visitor2.Value{"x": visitor2.Integer(5)}
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	result, err := visitor.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(5), result)
+
+	input.Name = "y"
+	_, err = visitor.Visit(input)
+	assert.NotNil(t, err)
+}
+
+func TestVisitor_VisitVariable_Indexes(t *testing.T) {
+	// a : ARRAY[1..2, 'a'..'b'] OF INTEGER
+	row := &visitor2.Array{Low: 'a', Elements: []visitor2.Value{visitor2.Integer(3), nil}, IndexType: "char"}
+	memory := map[string]visitor2.Value{
+		"a": &visitor2.Array{Low: 1, Elements: []visitor2.Value{row, row}, IndexType: "integer", ElementType: "array"},
+		"x": visitor2.Integer(1),
+	}
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+
+	indexed := func(name string, indexes ...ast.Expr) *ast.Variable {
+		return &ast.Variable{Name: name, Selectors: indexes}
+	}
+
+	result, err := visitor.Visit(indexed("a", number("2"), &ast.Char{Value: 'a'}))
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(3), result)
+
+	result, err = visitor.Visit(indexed("a", number("1")))
+	assert.Nil(t, err)
+	assert.Same(t, row, result)
+
+	errors := map[*ast.Variable]string{
+		indexed("a", number("3")):                                     "0:0: index 3 of a is out of range 1..2",
+		indexed("a", number("1"), &ast.Char{Value: 'c'}):              "0:0: index 'c' of a is out of range 'a'..'b'",
+		indexed("a", &ast.Char{Value: 'a'}):                           "0:0: expected an index of type INTEGER, got CHAR",
+		indexed("a", number("1"), &ast.Char{Value: 'b'}):              "0:0: element a[1, 'b'] is used before it is assigned a value",
+		indexed("a", number("1"), &ast.Char{Value: 'a'}, number("1")): "0:0: too many indexes for variable a",
+		indexed("x", number("1")):                                     "0:0: unable to index INTEGER variable x",
+	}
+	for input, expected := range errors {
+		_, err := visitor.Visit(input)
+		assert.EqualError(t, err, expected)
+	}
+}
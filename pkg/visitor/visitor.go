@@ -2,28 +2,145 @@
 package visitor
 
 import (
-	"errors"
+	"bufio"
 	"github.com/njirem95/simple-pascal/pkg/ast"
+	"io"
+	"os"
 )
 
+// Visitor interprets the abstract syntax tree, it implements ast.Visitor by executing every
+// type of node with its own method. The methods evaluate the nodes they contain through their
+// Accept method on the same visitor.
 type Visitor struct {
+	// GlobalMemory maps variable names to their current values.
+	GlobalMemory map[string]Value
+	// GlobalTypes maps the names of declared variables to the name of their type.
+	GlobalTypes map[string]string
+	// Stack contains the activation records of the program and the procedures and functions
+	// that are being executed.
+	Stack *CallStack
+	// Input is read by the standard procedures Read and ReadLn, Output is written by Write and
+	// WriteLn. Without Input the program has no input and without Output its output is
+	// discarded.
+	Input  io.Reader
+	Output io.Writer
 }
 
-func (v *Visitor) Visit(expression ast.Expr) (ast.Expr, error) {
-	switch expr := expression.(type) {
-	case *ast.BinOp:
-		node := BinOpVisitor{}
-		visit, err := node.Visit(expr)
-		return visit, err
-	case *ast.Num:
-		node := NumVisitor{}
-		visit, err := node.Visit(expr)
-		return visit, err
-	case *ast.UnaryOp:
-		node := UnaryVisitor{}
-		visit, err := node.Visit(expr)
-		return visit, err
+// Visit evaluates the expression and returns its value, statements and declarations are
+// executed and result in a nil value. The global memory and the call stack are created first
+// when they don't exist yet.
+func (v *Visitor) Visit(node ast.Node) (Value, error) {
+	v.prepare()
+	return v.evaluate(node)
+}
+
+// evaluate evaluates the node through its Accept method, which calls the method of the visitor
+// for its type. A method that results in anything else than a Value is a fault at the position
+// of the node.
+func (v *Visitor) evaluate(node ast.Node) (Value, error) {
+	if node == nil {
+		return nil, newRuntimeError(node, "visitor not found")
+	}
+
+	result, err := node.Accept(v)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	value, ok := result.(Value)
+	if !ok {
+		return nil, newRuntimeError(node, "expected the node to result in a value, got %T", result)
+	}
+	return value, nil
+}
+
+// prepare creates the global memory and the call stack when they don't exist yet. The input
+// and output of the visitor are used by the stack unless it already has them.
+func (v *Visitor) prepare() {
+	if v.GlobalMemory == nil {
+		v.GlobalMemory = make(map[string]Value)
+	}
+	if v.GlobalTypes == nil {
+		v.GlobalTypes = make(map[string]string)
+	}
+	v.Stack = callStack(v.Stack, v.GlobalMemory, v.GlobalTypes)
+	if v.Stack.Input == nil && v.Input != nil {
+		v.Stack.Input = bufio.NewReader(v.Input)
+	}
+	if v.Stack.Output == nil && v.Output != nil {
+		v.Stack.Output = v.Output
 	}
+}
+
+func (v *Visitor) VisitProgram(node *ast.Program) (interface{}, error) {
+	if node.Block == nil {
+		return nil, newRuntimeError(node, "the program has no block")
+	}
+	return v.evaluate(node.Block)
+}
+
+func (v *Visitor) VisitTypeSpec(node *ast.TypeSpec) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute type %s", node.Name)
+}
+
+func (v *Visitor) VisitSubrange(node *ast.Subrange) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute a subrange outside of its type")
+}
+
+func (v *Visitor) VisitParam(node *ast.Param) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute parameter %s", node.Variable.Name)
+}
+
+func (v *Visitor) VisitCaseBranch(node *ast.CaseBranch) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute a CASE branch outside of its CASE statement")
+}
+
+func (v *Visitor) VisitCaseLabel(node *ast.CaseLabel) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute a CASE label outside of its CASE statement")
+}
+
+func (v *Visitor) VisitEmpty(node *ast.Empty) (interface{}, error) {
+	return nil, nil
+}
+
+func (v *Visitor) VisitError(node *ast.Error) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute a statement that contains syntax errors")
+}
+
+func (v *Visitor) VisitBoolean(node *ast.Boolean) (interface{}, error) {
+	return Boolean(node.Value), nil
+}
+
+func (v *Visitor) VisitChar(node *ast.Char) (interface{}, error) {
+	return Char(node.Value), nil
+}
+
+func (v *Visitor) VisitString(node *ast.String) (interface{}, error) {
+	return String(node.Value), nil
+}
+
+func (v *Visitor) VisitField(node *ast.Field) (interface{}, error) {
+	return nil, newRuntimeError(node, "unable to execute field %s outside of its variable", node.Name)
+}
+
+func (v *Visitor) VisitWriteParam(node *ast.WriteParam) (interface{}, error) {
+	return nil, newRuntimeError(node, "only the arguments of Write and WriteLn can have a field width")
+}
+
+// Interpret executes the program against the global memory. Every fault of the program is
+// returned as a *RuntimeError at the position of the node that caused it.
+func (v *Visitor) Interpret(program *ast.Program) error {
+	_, err := v.Visit(program)
+	return err
+}
 
-	return nil, errors.New("visitor not found")
+// New creates the struct Visitor with an empty global memory, the program reads from the
+// standard input and writes to the standard output. Embedders can replace Input and Output
+// before the program is interpreted.
+func New() *Visitor {
+	visitor := &Visitor{}
+	visitor.GlobalMemory = make(map[string]Value)
+	visitor.GlobalTypes = make(map[string]string)
+	visitor.Input = os.Stdin
+	visitor.Output = os.Stdout
+	return visitor
 }
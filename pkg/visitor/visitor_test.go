@@ -3,13 +3,13 @@ package visitor_test
 import (
 	"github.com/njirem95/simple-pascal/pkg/ast"
 	"github.com/njirem95/simple-pascal/pkg/scanner/token"
-	"github.com/njirem95/simple-pascal/pkg/visitor"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
 func Test_Visit_BinOp(t *testing.T) {
-	expected := 35
+	expected := visitor2.Integer(35)
 	input := &ast.BinOp{
 		Left: &ast.Num{
 			Token: token.Token{
@@ -32,7 +32,7 @@ func Test_Visit_BinOp(t *testing.T) {
 		},
 	}
 
-	visitor := visitor.Visitor{}
+	visitor := visitor2.Visitor{}
 	res, err := visitor.Visit(input)
 	assert.Nil(t, err)
 
@@ -0,0 +1,28 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitWhile executes the body as long as the condition results in TRUE.
+func (v *Visitor) VisitWhile(statement *ast.While) (interface{}, error) {
+	for {
+		value, err := v.evaluate(statement.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		condition, ok := value.(Boolean)
+		if !ok {
+			return nil, newRuntimeError(statement.Condition, "expected the condition of WHILE to be a BOOLEAN, got %s", typeName(value))
+		}
+		if !condition {
+			return nil, nil
+		}
+
+		_, err = v.evaluate(statement.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package visitor
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+)
+
+// VisitWith executes the body, in which the fields of the records of the variables can be
+// accessed by their names. Every variable is evaluated once, before the body is executed, and is
+// resolved in the fields of the variables before it.
+func (v *Visitor) VisitWith(statement *ast.With) (interface{}, error) {
+	record := v.Stack.Peek()
+
+	count := len(record.With)
+	defer func() {
+		record.With = record.With[:count]
+	}()
+
+	for _, variable := range statement.Variables {
+		value, name, err := v.variable(variable)
+		if err != nil {
+			return nil, err
+		}
+
+		selected, ok := value.(*Record)
+		if !ok {
+			return nil, newRuntimeError(variable, "expected a RECORD variable in WITH, got %s", typeName(value))
+		}
+		record.With = append(record.With, WithScope{Record: selected, Name: name})
+	}
+
+	return v.evaluate(statement.Body)
+}
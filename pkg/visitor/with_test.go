@@ -0,0 +1,51 @@
+package visitor_test
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	"github.com/njirem95/simple-pascal/pkg/scanner/token"
+	visitor2 "github.com/njirem95/simple-pascal/pkg/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisitor_VisitWith(t *testing.T) {
+	point := &visitor2.Record{
+		Fields: map[string]visitor2.Value{},
+		Types:  map[string]string{"x": "integer"},
+	}
+	memory := map[string]visitor2.Value{"p": point, "x": visitor2.Integer(0)}
+	types := map[string]string{"p": "record", "x": "integer"}
+
+	// WITH p DO x := 1
+	input := &ast.With{
+		Variables: []*ast.Variable{{Name: "p", Token: token.Token{Type: token.Identifier, Lexeme: "p"}}},
+		Body:      assign("x", "1"),
+	}
+
+	visitor := visitor2.Visitor{GlobalMemory: memory, GlobalTypes: types}
+	_, err := visitor.Visit(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(1), point.Fields["x"])
+	assert.Equal(t, visitor2.Integer(0), memory["x"])
+
+	// Once the WITH statement has finished x refers to the variable again.
+	_, err = visitor.Visit(assign("x", "2"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, visitor2.Integer(2), memory["x"])
+	assert.Equal(t, visitor2.Integer(1), point.Fields["x"])
+}
+
+func TestVisitor_VisitWith_Error(t *testing.T) {
+	memory := map[string]visitor2.Value{"i": visitor2.Integer(1)}
+	input := &ast.With{
+		Variables: []*ast.Variable{{Name: "i"}},
+		Body:      &ast.Empty{},
+	}
+
+	visitor := visitor2.Visitor{GlobalMemory: memory}
+	_, err := visitor.Visit(input)
+
+	assert.EqualError(t, err, "0:0: expected a RECORD variable in WITH, got INTEGER")
+}
@@ -9,69 +9,106 @@ import (
 	"testing"
 )
 
+// span creates the span of a lexeme with the given length, starting at the given offset
+// of a single line input stream.
+func span(offset int, length int) token.Span {
+	return token.Span{
+		Start: token.Position{
+			Offset: offset,
+			Line:   1,
+			Column: offset + 1,
+		},
+		End: token.Position{
+			Offset: offset + length,
+			Line:   1,
+			Column: offset + length + 1,
+		},
+	}
+}
+
 // TestParser_Expr tests the integration between the lexer and the parser
 // and parses the expression 10 + (5 - (4 + -(6 - 1)))
 func TestParser_Expr(t *testing.T) {
 	expr := "10 + (5 - (4 + -(6 - 1)))"
 	expected := &ast.BinOp{
+		Span: span(0, 22),
 		Left: &ast.Num{
 			Token: token.Token{
 				Type:   token.Int,
 				Lexeme: "10",
+				Span:   span(0, 2),
 			},
 			Lexeme: "10",
+			Span:   span(0, 2),
 		},
 		Operator: token.Token{
 			Type:   token.Add,
 			Lexeme: "+",
+			Span:   span(3, 1),
 		},
 		Right: &ast.BinOp{
+			Span: span(6, 16),
 			Left: &ast.Num{
 				Token: token.Token{
 					Type:   token.Int,
 					Lexeme: "5",
+					Span:   span(6, 1),
 				},
 				Lexeme: "5",
+				Span:   span(6, 1),
 			},
 			Operator: token.Token{
 				Type:   token.Sub,
 				Lexeme: "-",
+				Span:   span(8, 1),
 			},
 			Right: &ast.BinOp{
+				Span: span(11, 11),
 				Left: &ast.Num{
 					Token: token.Token{
 						Type:   token.Int,
 						Lexeme: "4",
+						Span:   span(11, 1),
 					},
 					Lexeme: "4",
+					Span:   span(11, 1),
 				},
 				Operator: token.Token{
 					Type:   token.Add,
 					Lexeme: "+",
+					Span:   span(13, 1),
 				},
 				Right: &ast.UnaryOp{
+					Span: span(15, 7),
 					Operator: token.Token{
 						Type:   token.Sub,
 						Lexeme: "-",
+						Span:   span(15, 1),
 					},
 					Expression: &ast.BinOp{
+						Span: span(17, 5),
 						Left: &ast.Num{
 							Token: token.Token{
 								Type:   token.Int,
 								Lexeme: "6",
+								Span:   span(17, 1),
 							},
 							Lexeme: "6",
+							Span:   span(17, 1),
 						},
 						Operator: token.Token{
 							Type:   token.Sub,
 							Lexeme: "-",
+							Span:   span(19, 1),
 						},
 						Right: &ast.Num{
 							Token: token.Token{
 								Type:   token.Int,
 								Lexeme: "1",
+								Span:   span(21, 1),
 							},
 							Lexeme: "1",
+							Span:   span(21, 1),
 						},
 					},
 				},
@@ -91,3 +128,725 @@ func TestParser_Expr(t *testing.T) {
 
 	assert.Equal(t, expression, expected)
 }
+
+// TestParser_Program_Span tests whether the spans of the tokens are propagated into
+// the nodes of the abstract syntax tree.
+func TestParser_Program_Span(t *testing.T) {
+	input := "BEGIN\n    x := 2 * (3 + 4);\n    BEGIN\n        y := -x\n    END\nEND."
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 2)
+
+	assign, ok := statements[0].(*ast.Assign)
+	assert.True(t, ok)
+	assert.Equal(t, token.Position{Offset: 10, Line: 2, Column: 5}, assign.Span.Start)
+	assert.Equal(t, token.Position{Offset: 25, Line: 2, Column: 20}, assign.Span.End)
+
+	binOp, ok := assign.Right.(*ast.BinOp)
+	assert.True(t, ok)
+	assert.Equal(t, token.Position{Offset: 15, Line: 2, Column: 10}, binOp.Span.Start)
+
+	compound, ok := statements[1].(*ast.Compound)
+	assert.True(t, ok)
+	assert.Equal(t, token.Position{Offset: 32, Line: 3, Column: 5}, compound.Span.Start)
+	assert.Equal(t, token.Position{Offset: 61, Line: 5, Column: 8}, compound.Span.End)
+
+	unary, ok := compound.Statements[0].(*ast.Assign).Right.(*ast.UnaryOp)
+	assert.True(t, ok)
+	assert.Equal(t, token.Position{Offset: 51, Line: 4, Column: 14}, unary.Span.Start)
+	assert.Equal(t, token.Position{Offset: 53, Line: 4, Column: 16}, unary.Span.End)
+}
+
+// TestParser_Program_Recovery tests whether the parser reports every syntax error of a program
+// and still builds the statements that are correct.
+func TestParser_Program_Recovery(t *testing.T) {
+	input := `BEGIN
+    x := 1 +;
+    y := 2
+    z := (3;
+    BEGIN
+        a := 4 * ;
+        b := 5
+    END;
+    c := 6
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, errorList, 4)
+
+	assert.Equal(t, token.Position{Offset: 18, Line: 2, Column: 13}, errorList[0].Span.Start)
+	assert.Equal(t, []int{token.Semi, token.End}, errorList[1].Expected)
+	assert.Equal(t, token.Position{Offset: 35, Line: 4, Column: 5}, errorList[1].Span.Start)
+	assert.Equal(t, []int{token.Rparen}, errorList[2].Expected)
+	assert.Equal(t, token.Position{Offset: 71, Line: 6, Column: 18}, errorList[3].Span.Start)
+
+	assert.Len(t, statements, 5)
+	assert.IsType(t, &ast.Error{}, statements[0])
+	assert.IsType(t, &ast.Assign{}, statements[1])
+	assert.IsType(t, &ast.Error{}, statements[2])
+	assert.IsType(t, &ast.Assign{}, statements[4])
+
+	compound, ok := statements[3].(*ast.Compound)
+	assert.True(t, ok)
+	assert.Len(t, compound.Statements, 2)
+	assert.IsType(t, &ast.Error{}, compound.Statements[0])
+	assert.IsType(t, &ast.Assign{}, compound.Statements[1])
+}
+
+// TestParser_Program_MissingDot tests whether the statements are returned when the program
+// isn't terminated by a dot.
+func TestParser_Program_MissingDot(t *testing.T) {
+	lexer, err := scanner.New("BEGIN x := 1 END")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, errorList, 1)
+	assert.Equal(t, []int{token.Dot}, errorList[0].Expected)
+	assert.Len(t, statements, 1)
+}
+
+// TestParser_Program_Illegal tests whether illegal characters are reported as syntax errors.
+func TestParser_Program_Illegal(t *testing.T) {
+	lexer, err := scanner.New("BEGIN\n\tx := 1 # 2;\r\n\ty := 3;\r\n\t@\r\nEND.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, errorList, 2)
+	assert.Equal(t, "2:9: illegal character \"#\"", errorList[0].Error())
+	assert.Equal(t, "4:2: illegal character \"@\"", errorList[1].Error())
+
+	assert.Len(t, statements, 3)
+	assert.IsType(t, &ast.Error{}, statements[0])
+	assert.IsType(t, &ast.Assign{}, statements[1])
+	assert.IsType(t, &ast.Error{}, statements[2])
+}
+
+// TestParser_Program_Comments tests whether comments are ignored by the parser, and whether an
+// unterminated comment is reported as a syntax error.
+func TestParser_Program_Comments(t *testing.T) {
+	lexer, err := scanner.New("{ program }\nBEGIN\n\tx := 1; (* first *)\n\ty := 2 // second\nEND.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 2)
+	assert.Len(t, lexer.Comments, 3)
+
+	lexer, err = scanner.New("BEGIN\n\tx := 1 { unterminated\nEND.")
+	assert.Nil(t, err)
+
+	parser = parser2.New(lexer)
+	_, err = parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Equal(t, "2:9: unterminated comment", errorList[0].Error())
+}
+
+// TestParser_Program_Declarations tests the program header and the variable declarations.
+func TestParser_Program_Declarations(t *testing.T) {
+	input := `PROGRAM Part10;
+VAR
+    number     : INTEGER;
+    a, b, c, x : INTEGER;
+    y          : REAL;
+VAR
+    z : real;
+
+BEGIN
+    x := 1
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	assert.Equal(t, "part10", program.Name)
+	assert.Equal(t, token.Position{Offset: 0, Line: 1, Column: 1}, program.Span.Start)
+	assert.Equal(t, token.Position{Offset: 135, Line: 11, Column: 5}, program.Span.End)
+	assert.Len(t, program.Block.Compound.Statements, 1)
+
+	expected := []struct {
+		name     string
+		typeName string
+	}{
+		{"number", "integer"},
+		{"a", "integer"},
+		{"b", "integer"},
+		{"c", "integer"},
+		{"x", "integer"},
+		{"y", "real"},
+		{"z", "real"},
+	}
+
+	declarations := program.Block.Declarations
+	assert.Len(t, declarations, len(expected))
+	for i, declaration := range declarations {
+		varDecl, ok := declaration.(*ast.VarDecl)
+		assert.True(t, ok)
+		assert.Equal(t, expected[i].name, varDecl.Variable.Name)
+		assert.Equal(t, expected[i].typeName, varDecl.Type.Name)
+	}
+
+	varDecl := declarations[2].(*ast.VarDecl)
+	assert.Equal(t, token.Position{Offset: 53, Line: 4, Column: 8}, varDecl.Span.Start)
+	assert.Equal(t, token.Position{Offset: 70, Line: 4, Column: 25}, varDecl.Span.End)
+}
+
+// TestParser_Program_DeclarationRecovery tests whether the parser recovers from syntax errors
+// in the variable declarations.
+func TestParser_Program_DeclarationRecovery(t *testing.T) {
+	input := `PROGRAM Broken;
+VAR
+    a : INTEGER;
+    b : ;
+    c, : REAL;
+    d : INTEGER
+BEGIN
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Len(t, errorList, 3)
+	assert.Equal(t, []int{token.Identifier, token.Array, token.Record}, errorList[0].Expected)
+	assert.Equal(t, []int{token.Identifier}, errorList[1].Expected)
+	assert.Equal(t, []int{token.Semi}, errorList[2].Expected)
+
+	assert.Len(t, program.Block.Declarations, 2)
+}
+
+// TestParser_Program_CascadedErrors tests whether an error that follows from recovering from a
+// syntax error isn't reported at the position of that syntax error again.
+func TestParser_Program_CascadedErrors(t *testing.T) {
+	input := `PROGRAM Broken;
+VAR end.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	_, err = parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Len(t, errorList, 1)
+	assert.Equal(t, []int{token.Identifier}, errorList[0].Expected)
+	assert.Equal(t, token.Position{Offset: 20, Line: 2, Column: 5}, errorList[0].Span.Start)
+}
+
+// TestParser_Program_If tests whether an ELSE is bound to the nearest IF.
+func TestParser_Program_If(t *testing.T) {
+	input := `BEGIN
+    IF a THEN IF b THEN x := 1 ELSE x := 2;
+    IF a THEN BEGIN IF b THEN x := 1 END ELSE x := 2
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 2)
+
+	outer, ok := statements[0].(*ast.If)
+	assert.True(t, ok)
+	assert.Nil(t, outer.Else)
+	inner, ok := outer.Then.(*ast.If)
+	assert.True(t, ok)
+	assert.IsType(t, &ast.Assign{}, inner.Else)
+	assert.Equal(t, 5, outer.Span.Start.Column)
+	assert.Equal(t, 43, outer.Span.End.Column)
+
+	outer, ok = statements[1].(*ast.If)
+	assert.True(t, ok)
+	assert.IsType(t, &ast.Assign{}, outer.Else)
+	compound, ok := outer.Then.(*ast.Compound)
+	assert.True(t, ok)
+	inner, ok = compound.Statements[0].(*ast.If)
+	assert.True(t, ok)
+	assert.Nil(t, inner.Else)
+}
+
+// TestParser_Program_IfMissingThen tests the recovery from an IF statement without THEN.
+func TestParser_Program_IfMissingThen(t *testing.T) {
+	lexer, err := scanner.New("BEGIN IF a x := 1; y := 2 END.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Len(t, errorList, 1)
+	assert.Equal(t, "1:12: expected THEN, found identifier \"x\"", errorList[0].Error())
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 2)
+	assert.IsType(t, &ast.Error{}, statements[0])
+	assert.IsType(t, &ast.Assign{}, statements[1])
+}
+
+// TestParser_Program_Loops tests the WHILE, REPEAT and FOR statements.
+func TestParser_Program_Loops(t *testing.T) {
+	input := `BEGIN
+    WHILE i < 10 DO i := i + 1;
+    REPEAT i := i - 1; j := j + 1 UNTIL i = 0;
+    FOR i := 10 DOWNTO 1 DO BEGIN j := j + i END
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 3)
+
+	while, ok := statements[0].(*ast.While)
+	assert.True(t, ok)
+	assert.IsType(t, &ast.BinOp{}, while.Condition)
+	assert.IsType(t, &ast.Assign{}, while.Body)
+
+	repeat, ok := statements[1].(*ast.Repeat)
+	assert.True(t, ok)
+	assert.Len(t, repeat.Statements, 2)
+	assert.Equal(t, 5, repeat.Span.Start.Column)
+	assert.Equal(t, 46, repeat.Span.End.Column)
+
+	loop, ok := statements[2].(*ast.For)
+	assert.True(t, ok)
+	assert.Equal(t, "i", loop.Variable.Name)
+	assert.Equal(t, token.Downto, loop.Direction.Type)
+	assert.IsType(t, &ast.Compound{}, loop.Body)
+}
+
+// TestParser_Program_ForDirection tests the error of a FOR statement without TO or DOWNTO.
+func TestParser_Program_ForDirection(t *testing.T) {
+	lexer, err := scanner.New("BEGIN FOR i := 1 step 10 DO x := i END.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	_, err = parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Len(t, errorList, 1)
+	assert.Equal(t, "1:18: expected TO or DOWNTO, found identifier \"step\"", errorList[0].Error())
+}
+
+// TestParser_Program_Case tests the labels, the optional semicolon and the ELSE part of a
+// CASE statement.
+func TestParser_Program_Case(t *testing.T) {
+	input := `BEGIN
+    CASE x + 1 OF
+        1, 2: y := 1;
+        -3..-1, 5: BEGIN y := 2 END;
+        TRUE: ;
+    ELSE
+        y := 3;
+        z := 4
+    END;
+    CASE x OF 1: y := 1 END
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 2)
+
+	node, ok := statements[0].(*ast.Case)
+	assert.True(t, ok)
+	assert.IsType(t, &ast.BinOp{}, node.Expression)
+	assert.Len(t, node.Branches, 3)
+	assert.Len(t, node.Branches[0].Labels, 2)
+	assert.Nil(t, node.Branches[0].Labels[1].High)
+	assert.IsType(t, &ast.UnaryOp{}, node.Branches[1].Labels[0].Low)
+	assert.IsType(t, &ast.UnaryOp{}, node.Branches[1].Labels[0].High)
+	assert.IsType(t, &ast.Boolean{}, node.Branches[2].Labels[0].Low)
+	assert.IsType(t, &ast.Empty{}, node.Branches[2].Statement)
+	assert.Len(t, node.Else, 2)
+	assert.Equal(t, 2, node.Span.Start.Line)
+	assert.Equal(t, 9, node.Span.End.Line)
+
+	node, ok = statements[1].(*ast.Case)
+	assert.True(t, ok)
+	assert.Len(t, node.Branches, 1)
+	assert.Nil(t, node.Else)
+}
+
+// TestParser_Program_CaseLabel tests the error of a CASE label that isn't a constant.
+func TestParser_Program_CaseLabel(t *testing.T) {
+	lexer, err := scanner.New("BEGIN CASE x OF y: z := 1 END END.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	_, err = parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Equal(t, "1:17: expected '+', '-', integer, TRUE, FALSE or string, found identifier \"y\"", errorList[0].Error())
+}
+
+// TestParser_Program_Strings tests the CHAR and STRING literals and types.
+func TestParser_Program_Strings(t *testing.T) {
+	input := "VAR c : CHAR; s : STRING; BEGIN c := 'a'; s := 'it''s' + #33; CASE c OF 'a'..'z': END END."
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	declarations := program.Block.Declarations
+	assert.Equal(t, "char", declarations[0].(*ast.VarDecl).Type.Name)
+	assert.Equal(t, "string", declarations[1].(*ast.VarDecl).Type.Name)
+
+	statements := program.Block.Compound.Statements
+	assert.Equal(t, &ast.Char{
+		Token: token.Token{Type: token.String, Lexeme: "'a'", Span: span(37, 3)},
+		Value: 'a',
+		Span:  span(37, 3),
+	}, statements[0].(*ast.Assign).Right)
+
+	concatenation := statements[1].(*ast.Assign).Right.(*ast.BinOp)
+	assert.Equal(t, "it's", concatenation.Left.(*ast.String).Value)
+	assert.Equal(t, byte('!'), concatenation.Right.(*ast.Char).Value)
+
+	label := statements[2].(*ast.Case).Branches[0].Labels[0]
+	assert.Equal(t, byte('a'), label.Low.(*ast.Char).Value)
+	assert.Equal(t, byte('z'), label.High.(*ast.Char).Value)
+}
+
+// TestParser_Program_UnterminatedString tests the error of a string that isn't closed.
+func TestParser_Program_UnterminatedString(t *testing.T) {
+	lexer, err := scanner.New("BEGIN x := 'abc END.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	_, err = parser.Program()
+
+	errorList, ok := err.(parser2.ErrorList)
+	assert.True(t, ok)
+	assert.Equal(t, "1:12: unterminated string", errorList[0].Error())
+}
+
+// TestParser_Program_WriteParams tests the field width and the number of decimals of the
+// arguments of a procedure call.
+func TestParser_Program_WriteParams(t *testing.T) {
+	lexer, err := scanner.New("BEGIN WriteLn(x, y:8, z:w + 2:2); WriteLn END.")
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	statements := program.Block.Compound.Statements
+	call, ok := statements[0].(*ast.ProcedureCall)
+	assert.True(t, ok)
+	assert.Equal(t, "writeln", call.Name)
+	assert.Len(t, call.Arguments, 3)
+	assert.IsType(t, &ast.Variable{}, call.Arguments[0])
+
+	param := call.Arguments[1].(*ast.WriteParam)
+	assert.Equal(t, "y", param.Expression.(*ast.Variable).Name)
+	assert.Equal(t, "8", param.Width.(*ast.Num).Lexeme)
+	assert.Nil(t, param.Precision)
+	assert.Equal(t, span(17, 3), param.Span)
+
+	param = call.Arguments[2].(*ast.WriteParam)
+	assert.IsType(t, &ast.BinOp{}, param.Width)
+	assert.Equal(t, "2", param.Precision.(*ast.Num).Lexeme)
+	assert.Equal(t, span(22, 9), param.Span)
+
+	call, ok = statements[1].(*ast.ProcedureCall)
+	assert.True(t, ok)
+	assert.Nil(t, call.Arguments)
+}
+
+// TestParser_Program_Arrays tests the ARRAY types and the indexed variables.
+func TestParser_Program_Arrays(t *testing.T) {
+	input := "VAR a : ARRAY[1..3, 'a'..'z'] OF ARRAY[-1..+1] OF REAL; BEGIN a[i, j][k + 1] := a[1, 'b', 0] END."
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	typeSpec := program.Block.Declarations[0].(*ast.VarDecl).Type
+	assert.Equal(t, "array", typeSpec.Name)
+	assert.Len(t, typeSpec.Ranges, 2)
+	assert.Equal(t, "1", typeSpec.Ranges[0].Low.(*ast.Num).Lexeme)
+	assert.Equal(t, byte('z'), typeSpec.Ranges[1].High.(*ast.Char).Value)
+	assert.Equal(t, span(20, 8), typeSpec.Ranges[1].Span)
+	assert.Equal(t, span(8, 46), typeSpec.Span)
+
+	element := typeSpec.Element
+	assert.Equal(t, "array", element.Name)
+	assert.IsType(t, &ast.UnaryOp{}, element.Ranges[0].Low)
+	assert.Equal(t, "real", element.Element.Name)
+
+	assignment := program.Block.Compound.Statements[0].(*ast.Assign)
+	left := assignment.Left.(*ast.Variable)
+	assert.Equal(t, "a", left.Name)
+	assert.Len(t, left.Selectors, 3)
+	assert.IsType(t, &ast.BinOp{}, left.Selectors[2])
+	assert.Equal(t, span(62, 14), left.Span)
+
+	right := assignment.Right.(*ast.Variable)
+	assert.Len(t, right.Selectors, 3)
+	assert.Equal(t, span(80, 12), right.Span)
+}
+
+// TestParser_Program_ArrayErrors tests the syntax errors of ARRAY types and indexed variables.
+func TestParser_Program_ArrayErrors(t *testing.T) {
+	inputs := map[string]string{
+		"VAR a : ARRAY[1] OF INTEGER; BEGIN END.":        "1:16: expected '..', found ']'",
+		"VAR a : ARRAY[1..2] INTEGER; BEGIN END.":        "1:21: expected OF, found identifier \"integer\"",
+		"VAR a : ARRAY[x..2] OF INTEGER; BEGIN END.":     "1:15: expected '+', '-', integer, TRUE, FALSE or string, found identifier \"x\"",
+		"BEGIN a[1] END.":                                "1:12: expected ':=', found END",
+		"BEGIN a[1 := 2 END.":                            "1:11: expected ']', found ':='",
+		"VAR a : ARRAY[1..2] OF ARRAY; BEGIN END.":       "1:29: expected '[', found ';'",
+		"VAR a : ARRAY[1..2] OF INTEGER; BEGIN a[] END.": "1:41: expected '+', '-', NOT, integer, real number, TRUE, FALSE, string, '(' or identifier, found ']'",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		_, err = parser.Program()
+
+		errorList, ok := err.(parser2.ErrorList)
+		assert.True(t, ok, input)
+		if ok {
+			assert.Equal(t, message, errorList[0].Error(), input)
+		}
+	}
+}
+
+// TestParser_Program_Records tests the TYPE declarations, the RECORD types, the fields of
+// variables and the WITH statement.
+func TestParser_Program_Records(t *testing.T) {
+	input := "TYPE point = RECORD x, y : INTEGER END; VAR p : point; BEGIN p.x := 1; WITH p, q DO y := p.x END."
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	typeDecl := program.Block.Declarations[0].(*ast.TypeDecl)
+	assert.Equal(t, "point", typeDecl.Name)
+	assert.Equal(t, span(5, 33), typeDecl.Span)
+
+	record := typeDecl.Type
+	assert.Equal(t, "record", record.Name)
+	assert.Len(t, record.Fields, 2)
+	assert.Equal(t, "y", record.Fields[1].Variable.Name)
+	assert.Equal(t, "integer", record.Fields[1].Type.Name)
+	assert.Equal(t, span(13, 25), record.Span)
+
+	typeSpec := program.Block.Declarations[1].(*ast.VarDecl).Type
+	assert.Equal(t, token.Identifier, typeSpec.Token.Type)
+	assert.Equal(t, "point", typeSpec.Name)
+
+	statements := program.Block.Compound.Statements
+	left := statements[0].(*ast.Assign).Left.(*ast.Variable)
+	assert.Equal(t, "p", left.Name)
+	assert.Len(t, left.Selectors, 1)
+	assert.Equal(t, "x", left.Selectors[0].(*ast.Field).Name)
+	assert.Equal(t, span(61, 3), left.Span)
+
+	with := statements[1].(*ast.With)
+	assert.Len(t, with.Variables, 2)
+	assert.Equal(t, "q", with.Variables[1].Name)
+	assert.Equal(t, span(71, 21), with.Span)
+
+	body := with.Body.(*ast.Assign)
+	assert.Equal(t, "y", body.Left.(*ast.Variable).Name)
+	assert.Equal(t, span(89, 3), body.Right.(*ast.Variable).Span)
+}
+
+// TestParser_Program_RecordErrors tests the syntax errors of TYPE declarations, RECORD types,
+// fields and WITH statements.
+func TestParser_Program_RecordErrors(t *testing.T) {
+	inputs := map[string]string{
+		"TYPE point : INTEGER; BEGIN END.":            "1:12: expected '=', found ':'",
+		"TYPE point = RECORD END; BEGIN END.":         "1:21: expected identifier, found END",
+		"TYPE point = RECORD x : INTEGER; BEGIN END.": "1:34: expected identifier, found BEGIN",
+		"BEGIN p. := 1 END.":                          "1:10: expected identifier, found ':='",
+		"BEGIN p.1 := 1 END.":                         "1:9: expected identifier, found integer \"1\"",
+		"BEGIN WITH p x := 1 END.":                    "1:14: expected DO, found identifier \"x\"",
+		"BEGIN WITH DO x := 1 END.":                   "1:12: expected identifier, found DO",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		_, err = parser.Program()
+
+		errorList, ok := err.(parser2.ErrorList)
+		assert.True(t, ok, input)
+		if ok {
+			assert.Equal(t, message, errorList[0].Error(), input)
+		}
+	}
+}
+
+// TestParser_Program_Subroutines tests the declarations of procedures and functions and the
+// calls to them.
+func TestParser_Program_Subroutines(t *testing.T) {
+	input := `PROGRAM Subroutines;
+VAR x : INTEGER;
+PROCEDURE Reset;
+BEGIN
+    x := 0
+END;
+FUNCTION Max(a, b : INTEGER; c : REAL) : REAL;
+VAR m : REAL;
+    PROCEDURE Nested; BEGIN END;
+BEGIN
+    Max := a
+END;
+VAR y : REAL;
+BEGIN
+    Reset;
+    Reset(x);
+    y := Max(1, x + 1, 2.5) * 2
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	declarations := program.Block.Declarations
+	assert.Len(t, declarations, 4)
+
+	procedure, ok := declarations[1].(*ast.ProcedureDecl)
+	assert.True(t, ok)
+	assert.Equal(t, "reset", procedure.Name)
+	assert.Nil(t, procedure.Parameters)
+	assert.Equal(t, 3, procedure.Span.Start.Line)
+	assert.Equal(t, 6, procedure.Span.End.Line)
+
+	function, ok := declarations[2].(*ast.FunctionDecl)
+	assert.True(t, ok)
+	assert.Equal(t, "max", function.Name)
+	assert.Len(t, function.Parameters, 3)
+	assert.Equal(t, "b", function.Parameters[1].Variable.Name)
+	assert.Equal(t, "integer", function.Parameters[1].Type.Name)
+	assert.Equal(t, "real", function.Parameters[2].Type.Name)
+	assert.Equal(t, token.Identifier, function.ReturnType.Token.Type)
+	assert.Len(t, function.Block.Declarations, 2)
+	assert.IsType(t, &ast.ProcedureDecl{}, function.Block.Declarations[1])
+
+	assert.IsType(t, &ast.VarDecl{}, declarations[3])
+
+	statements := program.Block.Compound.Statements
+	assert.Len(t, statements, 3)
+
+	call, ok := statements[0].(*ast.ProcedureCall)
+	assert.True(t, ok)
+	assert.Equal(t, "reset", call.Name)
+	assert.Nil(t, call.Arguments)
+
+	call, ok = statements[1].(*ast.ProcedureCall)
+	assert.True(t, ok)
+	assert.Len(t, call.Arguments, 1)
+	assert.Equal(t, 13, call.Span.End.Column)
+
+	assign, ok := statements[2].(*ast.Assign)
+	assert.True(t, ok)
+	product, ok := assign.Right.(*ast.BinOp)
+	assert.True(t, ok)
+	max, ok := product.Left.(*ast.FunctionCall)
+	assert.True(t, ok)
+	assert.Equal(t, "max", max.Name)
+	assert.Len(t, max.Arguments, 3)
+	assert.IsType(t, &ast.BinOp{}, max.Arguments[1])
+}
+
+// TestParser_Program_ParameterModes tests the VAR and CONST parameters.
+func TestParser_Program_ParameterModes(t *testing.T) {
+	input := `PROCEDURE Swap(VAR a, b : INTEGER; CONST c : REAL; d : BOOLEAN); BEGIN END; BEGIN END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	procedure, ok := program.Block.Declarations[0].(*ast.ProcedureDecl)
+	assert.True(t, ok)
+
+	parameters := procedure.Parameters
+	assert.Len(t, parameters, 4)
+	assert.True(t, parameters[0].Reference)
+	assert.True(t, parameters[1].Reference)
+	assert.False(t, parameters[1].Constant)
+	assert.True(t, parameters[2].Constant)
+	assert.False(t, parameters[2].Reference)
+	assert.False(t, parameters[3].Reference)
+	assert.False(t, parameters[3].Constant)
+	assert.Equal(t, span(19, 14), parameters[0].Span)
+}
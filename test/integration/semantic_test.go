@@ -0,0 +1,331 @@
+package integration
+
+import (
+	"github.com/njirem95/simple-pascal/pkg/ast"
+	parser2 "github.com/njirem95/simple-pascal/pkg/parser"
+	"github.com/njirem95/simple-pascal/pkg/scanner"
+	"github.com/njirem95/simple-pascal/pkg/semantic"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// analyze parses the input and returns the semantic errors of the program.
+func analyze(t *testing.T, input string) (*semantic.Scope, []string) {
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err, input)
+
+	analyzer := semantic.New()
+	scope, err := analyzer.Analyze(program)
+	if err == nil {
+		return scope, nil
+	}
+
+	errorList, ok := err.(semantic.ErrorList)
+	assert.True(t, ok)
+
+	var messages []string
+	for _, semanticError := range errorList {
+		messages = append(messages, semanticError.Error())
+	}
+	return scope, messages
+}
+
+// TestAnalyzer_Analyze_SymbolTables tests the scopes built from the declarations.
+func TestAnalyzer_Analyze_SymbolTables(t *testing.T) {
+	input := `PROGRAM Scopes;
+VAR x, y : INTEGER;
+
+PROCEDURE Outer(a : REAL; VAR b : INTEGER);
+VAR x : BOOLEAN;
+    FUNCTION Inner : INTEGER;
+    BEGIN
+        Inner := b
+    END;
+BEGIN
+    x := TRUE;
+    b := Inner
+END;
+
+BEGIN
+    x := 1;
+    Outer(x, y)
+END.`
+
+	global, messages := analyze(t, input)
+	assert.Nil(t, messages)
+
+	assert.Equal(t, "scopes", global.Name)
+	assert.Equal(t, 0, global.Level)
+	assert.Len(t, global.Symbols, 3)
+	assert.Equal(t, semantic.Variable, global.Symbols["x"].Kind)
+	assert.Equal(t, "integer", global.Symbols["x"].Type)
+
+	outer := global.Symbols["outer"]
+	assert.Equal(t, semantic.Procedure, outer.Kind)
+	assert.Len(t, outer.Parameters, 2)
+	assert.Equal(t, "real", outer.Parameters[0].Type)
+
+	assert.Len(t, global.Children, 1)
+	scope := global.Children[0]
+	assert.Equal(t, outer, scope.Owner)
+	assert.Equal(t, 1, scope.Level)
+	assert.Equal(t, semantic.Parameter, scope.Symbols["b"].Kind)
+	assert.Equal(t, "boolean", scope.Lookup("x").Type)
+	assert.Equal(t, "integer", scope.Lookup("y").Type)
+
+	inner := scope.Symbols["inner"]
+	assert.Equal(t, semantic.Function, inner.Kind)
+	assert.Equal(t, "integer", inner.Type)
+	assert.Equal(t, 2, scope.Children[0].Level)
+}
+
+// TestAnalyzer_Analyze_Errors tests the undeclared identifiers, duplicate declarations and
+// variables that are used before they are assigned a value.
+func TestAnalyzer_Analyze_Errors(t *testing.T) {
+	inputs := map[string][]string{
+		"BEGIN x := 1 END.":                                                                        {"1:7: identifier x is not declared"},
+		"VAR x : INTEGER; BEGIN x := y END.":                                                       {"1:29: identifier y is not declared"},
+		"BEGIN p(1) END.":                                                                          {"1:7: procedure p is not declared"},
+		"VAR x : INTEGER; BEGIN x := f(1) END.":                                                    {"1:29: function f is not declared"},
+		"VAR x : INTEGER; x : REAL; BEGIN END.":                                                    {"1:18: variable x is declared more than once"},
+		"VAR p : INTEGER; PROCEDURE p; BEGIN END; BEGIN END.":                                      {"1:18: procedure p is declared more than once"},
+		"PROCEDURE p(a, a : INTEGER); BEGIN END; BEGIN END.":                                       {"1:16: parameter a is declared more than once"},
+		"PROCEDURE p(a : INTEGER); VAR a : REAL; BEGIN END; BEGIN END.":                            {"1:31: variable a is declared more than once"},
+		"VAR x, y : INTEGER; BEGIN y := x / 2 END.":                                                {"1:32: variable x is used before it is assigned a value"},
+		"VAR x, y : INTEGER; BEGIN y := x; y := x + x END.":                                        {"1:32: variable x is used before it is assigned a value"},
+		"VAR x, y : INTEGER; BEGIN IF TRUE THEN x := 1; y := x END.":                               {"1:53: variable x is used before it is assigned a value"},
+		"VAR x, y : INTEGER; BEGIN WHILE FALSE DO x := 1; y := x END.":                             {"1:55: variable x is used before it is assigned a value"},
+		"VAR i, y : INTEGER; BEGIN FOR i := 1 TO 2 DO y := i; y := i END.":                         {"1:59: variable i is used before it is assigned a value"},
+		"VAR x, y : INTEGER; BEGIN CASE 1 OF 1: x := 1; 2: y := 2 END; y := x END.":                {"1:68: variable x is used before it is assigned a value"},
+		"VAR x, y : INTEGER; PROCEDURE p; BEGIN END; BEGIN p; y := x / 2 END.":                     {"1:59: variable x is used before it is assigned a value"},
+		"VAR x, y : INTEGER; PROCEDURE p; BEGIN y := 1 END; BEGIN p; y := x END.":                  {"1:66: variable x is used before it is assigned a value"},
+		"VAR x, y : INTEGER; PROCEDURE p; VAR x : INTEGER; BEGIN x := 1 END; BEGIN p; y := x END.": {"1:83: variable x is used before it is assigned a value"},
+		"PROCEDURE p; BEGIN END; BEGIN p := 1 END.":                                                {"1:31: unable to assign to p, it isn't a variable"},
+		"FUNCTION f : INTEGER; BEGIN f := 1 END; BEGIN f := 1 END.":                                {"1:47: unable to assign to f, it isn't a variable"},
+		"PROCEDURE p; VAR x : INTEGER; BEGIN x := x + 1 END; BEGIN p END.":                         {"1:42: variable x is used before it is assigned a value"},
+		"VAR x : INTEGER; BEGIN WriteLn(x:y) END.":                                                 {"1:32: variable x is used before it is assigned a value", "1:34: identifier y is not declared"},
+		"VAR WriteLn : INTEGER; BEGIN WriteLn(1) END.":                                             {"1:30: procedure writeln is not declared"},
+		"VAR p : point; BEGIN END.":                                                                {"1:9: type point is not declared"},
+		"VAR x : INTEGER; p : x; BEGIN END.":                                                       {"1:22: variable x is not a type"},
+		"TYPE r = RECORD a : INTEGER; a : REAL END; BEGIN END.":                                    {"1:30: field a is declared more than once"},
+		"TYPE t = INTEGER; t = REAL; BEGIN END.":                                                   {"1:19: type t is declared more than once"},
+		"TYPE t = INTEGER; BEGIN t := 1 END.":                                                      {"1:25: unable to assign to t, it isn't a variable"},
+		"TYPE n = INTEGER; VAR i, j : n; BEGIN j := i END.":                                        {"1:44: variable i is used before it is assigned a value"},
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; BEGIN WITH p DO x := 1; x := 2 END.":          {"1:69: identifier x is not declared"},
+	}
+
+	for input, expected := range inputs {
+		_, messages := analyze(t, input)
+		assert.Equal(t, expected, messages, input)
+	}
+}
+
+// TestAnalyzer_Analyze_Assigned tests programs in which every variable is assigned before it
+// is used, on every path through the program.
+func TestAnalyzer_Analyze_Assigned(t *testing.T) {
+	inputs := []string{
+		"VAR x, y : INTEGER; BEGIN IF TRUE THEN x := 1 ELSE x := 2; y := x END.",
+		"VAR x, y : INTEGER; BEGIN REPEAT x := 1 UNTIL x = 1; y := x END.",
+		"VAR x, y : INTEGER; BEGIN CASE 1 OF 1: x := 1; 2: x := 2 ELSE x := 3 END; y := x END.",
+		"VAR x, y : INTEGER; PROCEDURE p(VAR a : INTEGER); BEGIN a := 1 END; BEGIN p(x); y := x END.",
+		"VAR x, y : INTEGER; PROCEDURE p; BEGIN x := 1 END; BEGIN p; y := x END.",
+		"VAR x : INTEGER; PROCEDURE p; VAR y : INTEGER; BEGIN y := x END; BEGIN x := 1; p END.",
+		"VAR x : INTEGER; FUNCTION f(n : INTEGER) : INTEGER; BEGIN IF n = 0 THEN f := 1 ELSE f := n * f(n - 1) END; BEGIN x := f(3) END.",
+		"VAR x, y : INTEGER; PROCEDURE p; BEGIN x := 1 END; PROCEDURE q; BEGIN p END; BEGIN q; y := x END.",
+		"VAR x, y : INTEGER; PROCEDURE p; PROCEDURE q; BEGIN x := 1 END; BEGIN q END; BEGIN p; y := x END.",
+		"VAR x, y : INTEGER; PROCEDURE p; BEGIN FOR x := 1 TO 2 DO; x := 2 END; BEGIN p; y := x END.",
+		"VAR x, y : INTEGER; BEGIN ReadLn(x, y); WriteLn(x + y) END.",
+		"PROCEDURE WriteLn(a : INTEGER); BEGIN END; BEGIN WriteLn(1) END.",
+		"BEGIN END.",
+	}
+
+	for _, input := range inputs {
+		_, messages := analyze(t, input)
+		assert.Nil(t, messages, input)
+	}
+}
+
+// check parses and analyzes the input and returns the checker together with the type errors of
+// the program.
+func check(t *testing.T, input string) (*ast.Program, *semantic.Checker, []string) {
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err, input)
+
+	scope, err := semantic.New().Analyze(program)
+	assert.Nil(t, err, input)
+
+	checker := semantic.NewChecker(scope)
+	err = checker.Check(program)
+	if err == nil {
+		return program, checker, nil
+	}
+
+	errorList, ok := err.(semantic.ErrorList)
+	assert.True(t, ok)
+
+	var messages []string
+	for _, semanticError := range errorList {
+		messages = append(messages, semanticError.Error())
+	}
+	return program, checker, messages
+}
+
+// TestChecker_Check_Types tests the static types the expressions are annotated with.
+func TestChecker_Check_Types(t *testing.T) {
+	inputs := map[string]*semantic.Type{
+		"1":                   semantic.Integer,
+		"1.5":                 semantic.Real,
+		"TRUE":                semantic.Boolean,
+		"i + 1":               semantic.Integer,
+		"i * r":               semantic.Real,
+		"i / 2":               semantic.Real,
+		"i DIV 2 MOD 3":       semantic.Integer,
+		"-r":                  semantic.Real,
+		"i < r":               semantic.Boolean,
+		"NOT b AND (i = 1)":   semantic.Boolean,
+		"Half(i)":             semantic.Real,
+		"Two":                 semantic.Integer,
+		"Two + Half(3)":       semantic.Real,
+		"'a'":                 semantic.Char,
+		"'ab'":                semantic.String,
+		"'a' + #10":           semantic.String,
+		"'a' < 'ab'":          semantic.Boolean,
+		"Abs(i)":              semantic.Integer,
+		"Sqr(r)":              semantic.Real,
+		"Sqrt(i)":             semantic.Real,
+		"Round(r) + Trunc(i)": semantic.Integer,
+		"Ord('a')":            semantic.Integer,
+		"Chr(65)":             semantic.Char,
+		"Succ(b)":             semantic.Boolean,
+		"Pred('b')":           semantic.Char,
+		"Odd(i)":              semantic.Boolean,
+		"a[i, 'b']":           semantic.Real,
+		"a[1]['c'] * i":       semantic.Real,
+		"a[Two][Chr(97)] > r": semantic.Boolean,
+		"p.x + i":             semantic.Integer,
+		"p.c[Two]":            semantic.Char,
+	}
+
+	for expression, expected := range inputs {
+		input := `TYPE point = RECORD x : INTEGER; c : ARRAY[1..2] OF CHAR END;
+VAR i : INTEGER; r : REAL; b : BOOLEAN; a : ARRAY[1..3, 'a'..'c'] OF REAL; p : point;
+FUNCTION Half(n : INTEGER) : REAL; BEGIN Half := n / 2 END;
+FUNCTION Two : INTEGER; BEGIN Two := 2 END;
+BEGIN
+    i := 1; r := 1; b := TRUE;
+    b := (` + expression + `) = (` + expression + `)
+END.`
+
+		program, checker, messages := check(t, input)
+		assert.Nil(t, messages, expression)
+
+		statements := program.Block.Compound.Statements
+		comparison := statements[len(statements)-1].(*ast.Assign).Right
+		assert.Equal(t, semantic.Boolean, checker.TypeOf(comparison), expression)
+		assert.Equal(t, expected, checker.TypeOf(comparison.(*ast.BinOp).Left), expression)
+	}
+}
+
+// TestChecker_Check_Errors tests the type errors that are reported before the program runs.
+func TestChecker_Check_Errors(t *testing.T) {
+	inputs := map[string][]string{
+		"VAR x : INTEGER; BEGIN x := 1.5 END.":                                                                                              {"1:24: unable to assign a value of type REAL to INTEGER variable x"},
+		"VAR x : INTEGER; BEGIN x := TRUE END.":                                                                                             {"1:24: unable to assign a value of type BOOLEAN to INTEGER variable x"},
+		"VAR x : REAL; BEGIN x := 1 END.":                                                                                                   nil,
+		"VAR x : INTEGER; BEGIN x := 1 / 2 END.":                                                                                            {"1:24: unable to assign a value of type REAL to INTEGER variable x"},
+		"VAR x : INTEGER; BEGIN x := 1.5 DIV 2 END.":                                                                                        {"1:29: operator DIV expects INTEGER operands, got REAL and INTEGER"},
+		"VAR x : BOOLEAN; BEGIN x := TRUE + 1 END.":                                                                                         {"1:29: operator + expects INTEGER or REAL operands, got BOOLEAN and INTEGER"},
+		"VAR x : BOOLEAN; BEGIN x := 1 AND TRUE END.":                                                                                       {"1:29: operator AND expects BOOLEAN operands, got INTEGER and BOOLEAN"},
+		"VAR x : BOOLEAN; BEGIN x := NOT 1 END.":                                                                                            {"1:33: operator NOT expects a BOOLEAN operand, got INTEGER"},
+		"VAR x : INTEGER; BEGIN x := -TRUE END.":                                                                                            {"1:30: operator - expects an INTEGER or REAL operand, got BOOLEAN"},
+		"VAR x : BOOLEAN; BEGIN x := TRUE < 1 END.":                                                                                         {"1:29: unable to compare BOOLEAN with INTEGER"},
+		"BEGIN IF 1 THEN END.":                                                                                                              {"1:10: expected the condition of IF to be a BOOLEAN, got INTEGER"},
+		"BEGIN WHILE 1.5 DO END.":                                                                                                           {"1:13: expected the condition of WHILE to be a BOOLEAN, got REAL"},
+		"BEGIN REPEAT UNTIL 0 END.":                                                                                                         {"1:20: expected the condition of UNTIL to be a BOOLEAN, got INTEGER"},
+		"VAR r : REAL; BEGIN FOR r := 1 TO 2.5 DO END.":                                                                                     {"1:25: expected control variable r to be an INTEGER, got REAL", "1:35: expected the bounds of FOR to be INTEGER, got REAL"},
+		"VAR i : INTEGER; BEGIN FOR i := 1 TO 3 DO BEGIN i := 2 END END.":                                                                   {"1:49: control variable i can't be assigned inside the FOR statement"},
+		"VAR i : INTEGER; BEGIN FOR i := 1 TO 3 DO FOR i := 1 TO 2 DO END.":                                                                 {"1:43: control variable i can't be assigned inside the FOR statement"},
+		"VAR i : INTEGER; BEGIN FOR i := 1 TO 3 DO CASE i OF 1: i := 2 END END.":                                                            {"1:56: control variable i can't be assigned inside the FOR statement"},
+		"VAR i : INTEGER; BEGIN FOR i := 1 TO 2 DO Read(i) END.":                                                                            {"1:43: control variable i can't be assigned inside the FOR statement"},
+		"VAR i : INTEGER; BEGIN FOR i := 1 TO 2 DO inc(i) END.":                                                                             {"1:43: control variable i can't be assigned inside the FOR statement"},
+		"TYPE r = RECORD i : INTEGER END; VAR i : INTEGER; p : r; BEGIN FOR i := 1 TO 2 DO WITH p DO i := 1 END.":                           nil,
+		"PROCEDURE p(CONST a : INTEGER); BEGIN FOR a := 1 TO 2 DO END; BEGIN p(1) END.":                                                     {"1:43: unable to use CONST parameter a as control variable"},
+		"BEGIN CASE 1.5 OF 1: END END.":                                                                                                     {"1:12: expected the expression of CASE to be of an ordinal type, got REAL"},
+		"BEGIN CASE 1 OF TRUE: ; 2..FALSE: END END.":                                                                                        {"1:17: expected CASE label to be of type INTEGER, got BOOLEAN", "1:28: expected CASE label to be of type INTEGER, got BOOLEAN"},
+		"BEGIN CASE 'a' OF 1: END END.":                                                                                                     {"1:19: expected CASE label to be of type CHAR, got INTEGER"},
+		"BEGIN CASE 1 OF 1, 2: ; 2..5: END END.":                                                                                            {"1:25: CASE label 2..5 overlaps with label 2"},
+		"BEGIN CASE 1 OF 1: ; 1: END END.":                                                                                                  {"1:22: CASE label 1 overlaps with label 1"},
+		"BEGIN CASE 1 OF 3..1: END END.":                                                                                                    {"1:17: CASE label range 3..1 is empty"},
+		"BEGIN CASE FALSE OF TRUE: ; FALSE..TRUE: END END.":                                                                                 {"1:29: CASE label FALSE..TRUE overlaps with label TRUE"},
+		"BEGIN CASE 1 OF -2..-1: ; 0: ; -1: END END.":                                                                                       {"1:32: CASE label -1 overlaps with label -2..-1"},
+		"BEGIN CASE 'a' OF 'a'..'c': ; 'd'..'z', 'b': END END.":                                                                             {"1:41: CASE label 'b' overlaps with label 'a'..'c'"},
+		"PROCEDURE p(a : INTEGER); BEGIN END; BEGIN p(1.5) END.":                                                                            {"1:46: unable to pass a value of type REAL as INTEGER parameter a"},
+		"PROCEDURE p(a, b : INTEGER); BEGIN END; BEGIN p(1) END.":                                                                           {"1:47: p expects 2 arguments, got 1"},
+		"PROCEDURE p(a : INTEGER); BEGIN END; BEGIN p(1, 2) END.":                                                                           {"1:44: p expects 1 argument, got 2"},
+		"VAR r : REAL; PROCEDURE p(VAR a : INTEGER); BEGIN END; BEGIN p(r) END.":                                                            {"1:64: unable to pass REAL variable r as INTEGER VAR parameter a"},
+		"PROCEDURE p(CONST a : INTEGER); BEGIN a := 1 END; BEGIN p(1) END.":                                                                 {"1:39: unable to assign to CONST parameter a"},
+		"TYPE t = ARRAY[1..2] OF INTEGER; PROCEDURE p(CONST a : t); BEGIN a[1] := 1 END; VAR x : t; BEGIN p(x) END.":                        {"1:66: unable to assign to CONST parameter a"},
+		"TYPE r = RECORD x : INTEGER END; PROCEDURE p(CONST a : r); BEGIN WITH a DO x := 1 END; VAR v : r; BEGIN p(v) END.":                 {"1:76: unable to assign to field x of CONST parameter a"},
+		"PROCEDURE p(CONST a : INTEGER); BEGIN Read(a); Inc(a) END; BEGIN p(1) END.":                                                        {"1:44: unable to assign to CONST parameter a", "1:52: unable to assign to CONST parameter a"},
+		"PROCEDURE q(VAR b : INTEGER); BEGIN END; PROCEDURE p(CONST a : INTEGER); BEGIN q(a) END; BEGIN p(1) END.":                          {"1:82: unable to pass a as argument for VAR parameter b"},
+		"PROCEDURE p(VAR a : INTEGER); BEGIN END; BEGIN p(1) END.":                                                                          {"1:50: expected a variable as argument for VAR parameter a"},
+		"VAR x : INTEGER; PROCEDURE p; BEGIN END; BEGIN x := p END.":                                                                        {"1:53: procedure p doesn't return a value"},
+		"VAR x : INTEGER; FUNCTION f(a : INTEGER) : INTEGER; BEGIN f := a END; BEGIN x := f END.":                                           {"1:82: f expects 1 argument, got 0"},
+		"VAR x : INTEGER; FUNCTION f : BOOLEAN; BEGIN f := 1 END; BEGIN x := 1 + f END.":                                                    {"1:46: unable to assign a value of type INTEGER to BOOLEAN variable f", "1:69: operator + expects INTEGER or REAL operands, got INTEGER and BOOLEAN"},
+		"VAR s : STRING; BEGIN s := 'a'; s := s + #10 END.":                                                                                 nil,
+		"VAR c : CHAR; BEGIN c := 'ab' END.":                                                                                                {"1:21: unable to assign a value of type STRING to CHAR variable c"},
+		"VAR x : INTEGER; BEGIN x := 'ab' END.":                                                                                             {"1:24: unable to assign a value of type STRING to INTEGER variable x"},
+		"VAR x : STRING; BEGIN x := 'a' + 1 END.":                                                                                           {"1:28: operator + expects CHAR or STRING operands, got CHAR and INTEGER"},
+		"VAR x : BOOLEAN; BEGIN x := 'a' = 1 END.":                                                                                          {"1:29: unable to compare CHAR with INTEGER"},
+		"BEGIN CASE 'a' OF 'b': ; 1: END END.":                                                                                              {"1:26: expected CASE label to be of type CHAR, got INTEGER"},
+		"VAR i : INTEGER; r : REAL; BEGIN Read(i, r); WriteLn(i:4, r:8:2, 'a':i, TRUE) END.":                                                nil,
+		"VAR b : BOOLEAN; BEGIN Read(b) END.":                                                                                               {"1:29: unable to read BOOLEAN variable b"},
+		"VAR i : INTEGER; BEGIN i := 1; ReadLn(i + 1) END.":                                                                                 {"1:39: expected a variable as argument of readln"},
+		"BEGIN WriteLn(1:1.5, 2:3:4) END.":                                                                                                  {"1:17: expected the field width to be an INTEGER, got REAL", "1:26: only REAL values can be written with a number of decimals, got INTEGER"},
+		"BEGIN WriteLn(1.5:2:TRUE) END.":                                                                                                    {"1:21: expected the number of decimals to be an INTEGER, got BOOLEAN"},
+		"PROCEDURE p(a : INTEGER); BEGIN END; BEGIN p(1:2) END.":                                                                            {"1:46: only the arguments of Write and WriteLn can have a field width"},
+		"VAR x : INTEGER; BEGIN x := Sqrt(4) END.":                                                                                          {"1:24: unable to assign a value of type REAL to INTEGER variable x"},
+		"VAR x : REAL; BEGIN x := Sin(TRUE) END.":                                                                                           {"1:30: sin expects an INTEGER or REAL argument, got BOOLEAN"},
+		"VAR x : CHAR; BEGIN x := Chr('a') END.":                                                                                            {"1:30: chr expects an INTEGER argument, got CHAR"},
+		"VAR x : INTEGER; BEGIN x := Ord(1.5) + Abs(1, 2) END.":                                                                             {"1:33: ord expects an argument of an ordinal type, got REAL", "1:40: abs expects 1 argument, got 2"},
+		"VAR x : INTEGER; BEGIN x := Abs END.":                                                                                              {"1:29: abs expects 1 argument, got 0"},
+		"VAR r : REAL; BEGIN r := 1; Inc(r); Dec(r, 1.5, 2) END.":                                                                           {"1:33: inc expects a variable of an ordinal type, got REAL", "1:37: dec expects 1 or 2 arguments, got 3"},
+		"VAR c : CHAR; BEGIN c := 'a'; Inc(c, 'b'); Dec(1) END.":                                                                            {"1:38: inc expects an INTEGER as second argument, got CHAR", "1:48: expected a variable as argument of dec"},
+		"VAR x : INTEGER; FUNCTION Abs(b : BOOLEAN) : INTEGER; BEGIN Abs := 1 END; BEGIN x := Abs(TRUE) END.":                               nil,
+		"VAR x : INTEGER; BEGIN x := TRUE; IF x THEN x := 1.5 END.":                                                                         {"1:24: unable to assign a value of type BOOLEAN to INTEGER variable x", "1:38: expected the condition of IF to be a BOOLEAN, got INTEGER", "1:45: unable to assign a value of type REAL to INTEGER variable x"},
+		"VAR a : ARRAY[1..3] OF INTEGER; BEGIN a['a'] := 1.5 END.":                                                                          {"1:41: expected an index of type INTEGER, got CHAR", "1:39: unable to assign a value of type REAL to INTEGER variable a"},
+		"VAR a : ARRAY[1..3, 'a'..'b'] OF INTEGER; BEGIN a[1]['a'] := a[1, 'b'] + a[1][2] END.":                                             {"1:79: expected an index of type CHAR, got INTEGER"},
+		"VAR a : ARRAY[1..3] OF INTEGER; i : INTEGER; BEGIN i := 1; i := a[1, 2] + i[1] END.":                                               {"1:70: too many indexes for ARRAY[1..3] OF INTEGER variable a", "1:77: unable to index INTEGER variable i"},
+		"VAR a : ARRAY[5..4] OF INTEGER; b : ARRAY[1..'c'] OF INTEGER; c : ARRAY[1..99999999999999999999] OF INTEGER; BEGIN END.":           {"1:15: index range 5..4 is empty", "1:43: expected the bounds of an index range to be of the same type, got INTEGER and CHAR", "1:76: integer 99999999999999999999 is out of range"},
+		"VAR a : ARRAY[1..3] OF INTEGER; b : ARRAY[0..2] OF INTEGER; c : ARRAY[1..3] OF INTEGER; BEGIN a := c; a := b END.":                 {"1:103: unable to assign a value of type ARRAY[0..2] OF INTEGER to ARRAY[1..3] OF INTEGER variable a"},
+		"VAR a : ARRAY[1..3] OF INTEGER; b : BOOLEAN; BEGIN b := a = a; WriteLn(a) END.":                                                    {"1:57: unable to compare ARRAY[1..3] OF INTEGER with ARRAY[1..3] OF INTEGER", "1:72: unable to write a value of type ARRAY[1..3] OF INTEGER"},
+		"FUNCTION f : ARRAY[1..3] OF INTEGER; BEGIN END; BEGIN END.":                                                                        {"1:14: expected the result of function f to be of a simple type, got ARRAY[1..3] OF INTEGER"},
+		"VAR a : ARRAY[1..3] OF INTEGER; PROCEDURE p(VAR x : INTEGER; VAR y : ARRAY[1..3] OF INTEGER); BEGIN END; BEGIN p(a[1], a) END.":    nil,
+		"VAR a : ARRAY[1..3] OF REAL; PROCEDURE p(VAR x : INTEGER); BEGIN END; BEGIN p(a[1]) END.":                                          {"1:79: unable to pass REAL element of a as INTEGER VAR parameter x"},
+		"VAR a : ARRAY[1..3] OF INTEGER; PROCEDURE p(y : ARRAY[1..4] OF INTEGER); BEGIN END; BEGIN p(a) END.":                               {"1:93: unable to pass a value of type ARRAY[1..3] OF INTEGER as ARRAY[1..4] OF INTEGER parameter y"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p : point; BEGIN p.x := TRUE; p.z := 1 END.":                                           {"1:62: unable to assign a value of type BOOLEAN to INTEGER variable p", "1:77: point has no field z"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p : point; i : INTEGER; BEGIN i := 1; WITH i DO END.":                                  {"1:88: expected a RECORD variable in WITH, got INTEGER"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p : point; BEGIN WITH p DO x := 'a' END.":                                              {"1:72: unable to assign a value of type CHAR to INTEGER field x"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p : point; BEGIN WITH p DO FOR x := 1 TO 2 DO END.":                                    {"1:76: unable to use field x as control variable"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p : point; PROCEDURE q(VAR i : INTEGER); BEGIN END; BEGIN q(p.x); WITH p DO q(y) END.": nil,
+		"TYPE point = RECORD x : INTEGER; b : BOOLEAN END; VAR p : point; PROCEDURE q(VAR i : INTEGER); BEGIN END; BEGIN q(p.b); WITH p DO q(b) END.":    {"1:115: unable to pass BOOLEAN field of p as INTEGER VAR parameter i", "1:133: unable to pass BOOLEAN field of p as INTEGER VAR parameter i"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p, q : point; b : BOOLEAN; BEGIN b := p = q; WriteLn(p) END.":                                       {"1:83: unable to compare point with point", "1:98: unable to write a value of type point"},
+		"TYPE point = RECORD x, y : INTEGER END; FUNCTION f : point; BEGIN END; BEGIN END.":                                                              {"1:54: expected the result of function f to be of a simple type, got point"},
+		"TYPE point = RECORD x, y : INTEGER END; VAR p : point; q : RECORD x, y : INTEGER END; r : RECORD y, x : INTEGER END; BEGIN p := q; p := r END.": {"1:132: unable to assign a value of type RECORD to point variable p"},
+		"TYPE point = RECORD x : INTEGER; a : ARRAY[1..2] OF INTEGER END; VAR p : point; BEGIN p.x[1] := 1; p.a[1, 2] := 1; p.x.y := 1 END.":             {"1:91: unable to index INTEGER field x", "1:107: too many indexes for ARRAY[1..2] OF INTEGER field a", "1:120: INTEGER has no field y"},
+		"TYPE point = RECORD x : INTEGER END; VAR x : INTEGER; p : point; BEGIN x := point END.":                                                         {"1:77: unable to use type point as a value"},
+	}
+
+	for input, expected := range inputs {
+		_, _, messages := check(t, input)
+		assert.Equal(t, expected, messages, input)
+	}
+}
@@ -1,25 +1,39 @@
 package integration
 
 import (
+	"bytes"
 	parser2 "github.com/njirem95/simple-pascal/pkg/parser"
 	"github.com/njirem95/simple-pascal/pkg/scanner"
 	"github.com/njirem95/simple-pascal/pkg/visitor"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
 // We'll be testing the integration of the visitor pattern. The visitor pattern
 // 'visits' the abstract syntax tree; therefore interpreting the expression.
 func TestVisitor_Expression(t *testing.T) {
-	inputs := make(map[string]int)
-	inputs["2"] = 2
-	inputs["2 + 2"] = 4
-	inputs["9 * 2 - 2 + 4"] = 20
-	inputs["2 + 2 * 4"] = 10
-	inputs["(2 + 2) * 4"] = 16
-	inputs["(512 * 2) - (28 - (16 / 4))"] = 1000
-	inputs["6 - - - + - 4"] = 10
-	inputs["6 - - - + - (3 + 4) - +1"] = 12
+	inputs := make(map[string]visitor.Value)
+	inputs["2"] = visitor.Integer(2)
+	inputs["2 + 2"] = visitor.Integer(4)
+	inputs["9 * 2 - 2 + 4"] = visitor.Integer(20)
+	inputs["2 + 2 * 4"] = visitor.Integer(10)
+	inputs["(2 + 2) * 4"] = visitor.Integer(16)
+	inputs["(512 * 2) - (28 - (16 / 4))"] = visitor.Real(1000.0)
+	inputs["6 - - - + - 4"] = visitor.Integer(10)
+	inputs["6 - - - + - (3 + 4) - +1"] = visitor.Integer(12)
+	inputs["7 / 2"] = visitor.Real(3.5)
+	inputs["1.5 * 4"] = visitor.Real(6.0)
+	inputs["2 + 0.25"] = visitor.Real(2.25)
+	inputs["-2.5E1 + 1e2"] = visitor.Real(75.0)
+	inputs["10 / 4 * 2"] = visitor.Real(5.0)
+	inputs["7 div 2"] = visitor.Integer(3)
+	inputs["-7 DIV 2"] = visitor.Integer(-3)
+	inputs["7 mod 3"] = visitor.Integer(1)
+	inputs["-7 mod 3"] = visitor.Integer(-1)
+	inputs["7 mod -3"] = visitor.Integer(1)
+	inputs["2 + 17 div 5 * 3"] = visitor.Integer(11)
+	inputs["(17 mod 5) / 4"] = visitor.Real(0.5)
 
 	for input, result := range inputs {
 		lexer, err := scanner.New(input)
@@ -37,3 +51,1135 @@ func TestVisitor_Expression(t *testing.T) {
 		assert.Equal(t, result, visit)
 	}
 }
+
+// TestVisitor_Expression_Boolean tests the relational and logical operators. Relational operators
+// bind weaker than the arithmetic operators, AND binds like multiplication and OR like addition.
+func TestVisitor_Expression_Boolean(t *testing.T) {
+	inputs := make(map[string]visitor.Value)
+	inputs["TRUE"] = visitor.Boolean(true)
+	inputs["false"] = visitor.Boolean(false)
+	inputs["1 < 2"] = visitor.Boolean(true)
+	inputs["2 <= 2"] = visitor.Boolean(true)
+	inputs["3 > 4"] = visitor.Boolean(false)
+	inputs["4 >= 5"] = visitor.Boolean(false)
+	inputs["1 = 1.0"] = visitor.Boolean(true)
+	inputs["1 <> 2"] = visitor.Boolean(true)
+	inputs["2.5 > 2"] = visitor.Boolean(true)
+	inputs["1 + 2 * 3 = 7"] = visitor.Boolean(true)
+	inputs["FALSE < TRUE"] = visitor.Boolean(true)
+	inputs["TRUE = TRUE"] = visitor.Boolean(true)
+	inputs["not TRUE"] = visitor.Boolean(false)
+	inputs["not not TRUE"] = visitor.Boolean(true)
+	inputs["TRUE and FALSE"] = visitor.Boolean(false)
+	inputs["TRUE or FALSE"] = visitor.Boolean(true)
+	inputs["FALSE or TRUE and FALSE"] = visitor.Boolean(false)
+	inputs["(1 < 2) and (2 < 3)"] = visitor.Boolean(true)
+	inputs["not (1 > 2) or FALSE"] = visitor.Boolean(true)
+	inputs["FALSE and (1 div 0 = 0)"] = visitor.Boolean(false)
+	inputs["TRUE or (1 div 0 = 0)"] = visitor.Boolean(true)
+
+	for input, result := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+
+		expression, err := parser.Expr()
+		assert.Nil(t, err, input)
+
+		visitor := visitor.Visitor{}
+		visit, err := visitor.Visit(expression)
+		assert.Nil(t, err, input)
+
+		assert.Equal(t, result, visit, input)
+	}
+}
+
+// TestVisitor_Program interprets a complete program and inspects the global memory afterwards.
+func TestVisitor_Program(t *testing.T) {
+	input := `BEGIN
+    number := 123;
+    BEGIN
+        x := 12;
+        y := x / 2
+    END;
+    z := number - y
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(123), interpreter.GlobalMemory["number"])
+	assert.Equal(t, visitor.Integer(12), interpreter.GlobalMemory["x"])
+	assert.Equal(t, visitor.Real(6.0), interpreter.GlobalMemory["y"])
+	assert.Equal(t, visitor.Real(117.0), interpreter.GlobalMemory["z"])
+}
+
+// TestVisitor_Program_Declarations tests whether declared variables are allocated with their type.
+func TestVisitor_Program_Declarations(t *testing.T) {
+	input := `PROGRAM Declarations;
+VAR
+    x, unused : INTEGER;
+    y         : REAL;
+BEGIN
+    x := 3;
+    y := x * 2
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]string{"x": "integer", "unused": "integer", "y": "real"}, interpreter.GlobalTypes)
+	assert.Equal(t, visitor.Integer(3), interpreter.GlobalMemory["x"])
+	assert.Equal(t, visitor.Real(6.0), interpreter.GlobalMemory["y"])
+
+	_, ok := interpreter.GlobalMemory["unused"]
+	assert.False(t, ok)
+}
+
+// TestVisitor_Program_Real tests whether integers are widened when assigned to a REAL variable
+// and whether assigning a REAL to an INTEGER variable fails.
+func TestVisitor_Program_Real(t *testing.T) {
+	input := `PROGRAM Reals;
+VAR
+    i : INTEGER;
+    r : REAL;
+BEGIN
+    r := 2;
+    i := r / 2
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, visitor.Real(2.0), interpreter.GlobalMemory["r"])
+	_, ok := interpreter.GlobalMemory["i"]
+	assert.False(t, ok)
+}
+
+// TestVisitor_Expression_RuntimeError tests whether runtime faults are returned as a RuntimeError
+// that refers to the offending expression, instead of causing a panic.
+func TestVisitor_Expression_RuntimeError(t *testing.T) {
+	inputs := []struct {
+		input   string
+		message string
+		start   int
+		end     int
+	}{
+		{"1 / 0", "division by zero", 0, 5},
+		{"2 * (1.5 / (3 - 3))", "division by zero", 5, 17},
+		{"1 div 0", "division by zero", 0, 7},
+		{"1 mod (2 - 2)", "division by zero", 0, 12},
+		{"1.5 div 1", "operator DIV expects INTEGER operands", 0, 9},
+		{"4 mod 2.0", "operator MOD expects INTEGER operands", 0, 9},
+		{"9223372036854775807 + 1", "integer overflow", 0, 23},
+		{"-9223372036854775807 - 2", "integer overflow", 0, 24},
+		{"4611686018427387904 * 2", "integer overflow", 0, 23},
+		{"1e308 * 10", "floating point overflow", 0, 10},
+		{"99999999999999999999", "invalid integer 99999999999999999999", 0, 20},
+		{"1 + x", "variable x is used before it is assigned a value", 4, 5},
+		{"1 < TRUE", "unable to compare INTEGER with BOOLEAN", 0, 8},
+		{"TRUE and 1", "operator AND expects BOOLEAN operands, got INTEGER", 0, 10},
+		{"0 or FALSE", "operator OR expects BOOLEAN operands, got INTEGER", 0, 10},
+		{"not 1", "operator NOT expects a BOOLEAN operand, got INTEGER", 4, 5},
+		{"-TRUE", "expected operand to be a number, got BOOLEAN", 1, 5},
+		{"TRUE + 1", "expected left to be a number, got BOOLEAN", 0, 4},
+	}
+
+	for _, input := range inputs {
+		lexer, err := scanner.New(input.input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+
+		expression, err := parser.Expr()
+		assert.Nil(t, err)
+
+		interpreter := visitor.Visitor{}
+		_, err = interpreter.Visit(expression)
+
+		runtimeError, ok := err.(*visitor.RuntimeError)
+		assert.True(t, ok, input.input)
+		assert.Equal(t, input.message, runtimeError.Message, input.input)
+		assert.Equal(t, input.start, runtimeError.Span.Start.Offset, input.input)
+		assert.Equal(t, input.end, runtimeError.Span.End.Offset, input.input)
+	}
+}
+
+// TestVisitor_Program_RuntimeError tests whether the error of a failing statement refers to
+// its position in the program.
+func TestVisitor_Program_RuntimeError(t *testing.T) {
+	input := `PROGRAM Failing;
+VAR
+    x, y : INTEGER;
+BEGIN
+    x := 10;
+    y := x DIV (x - 10)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.EqualError(t, err, "6:10: division by zero")
+}
+
+// TestVisitor_Program_Boolean tests assigning to BOOLEAN variables.
+func TestVisitor_Program_Boolean(t *testing.T) {
+	input := `PROGRAM Booleans;
+VAR
+    x : INTEGER;
+    small, done : BOOLEAN;
+BEGIN
+    x := 3;
+    small := x < 10;
+    done := not small or (x = 3);
+    x := done
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.EqualError(t, err, "9:5: unable to assign a value of type BOOLEAN to INTEGER variable x")
+
+	assert.Equal(t, visitor.Boolean(true), interpreter.GlobalMemory["small"])
+	assert.Equal(t, visitor.Boolean(true), interpreter.GlobalMemory["done"])
+	assert.Equal(t, visitor.Integer(3), interpreter.GlobalMemory["x"])
+}
+
+// TestVisitor_Program_If tests nested IF statements inside compound statements.
+func TestVisitor_Program_If(t *testing.T) {
+	input := `PROGRAM Conditions;
+VAR
+    x, sign, parity : INTEGER;
+    big : BOOLEAN;
+BEGIN
+    x := -7;
+    IF x < 0 THEN
+        sign := -1
+    ELSE IF x = 0 THEN
+        sign := 0
+    ELSE
+        sign := 1;
+    BEGIN
+        IF x MOD 2 = 0 THEN parity := 0 ELSE BEGIN
+            parity := 1;
+            IF x > 100 THEN big := TRUE ELSE big := FALSE
+        END
+    END;
+    IF FALSE THEN IF TRUE THEN x := 1 ELSE x := 2
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(-7), interpreter.GlobalMemory["x"])
+	assert.Equal(t, visitor.Integer(-1), interpreter.GlobalMemory["sign"])
+	assert.Equal(t, visitor.Integer(1), interpreter.GlobalMemory["parity"])
+	assert.Equal(t, visitor.Boolean(false), interpreter.GlobalMemory["big"])
+}
+
+// TestVisitor_Program_Loops tests the execution of the WHILE, REPEAT and FOR statements.
+func TestVisitor_Program_Loops(t *testing.T) {
+	input := `PROGRAM Loops;
+VAR
+    i, j, n, sum, count : INTEGER;
+BEGIN
+    n := 0;
+    WHILE n < 5 DO n := n + 2;
+    REPEAT count := 1 UNTIL TRUE;
+    sum := 0;
+    FOR i := 1 TO 4 DO
+        FOR j := i DOWNTO 1 DO
+            sum := sum + j;
+    FOR i := 5 TO 1 DO
+        count := count + 1;
+    j := 9223372036854775806;
+    FOR i := j TO 9223372036854775807 DO
+        count := count + 1
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(6), interpreter.GlobalMemory["n"])
+	assert.Equal(t, visitor.Integer(20), interpreter.GlobalMemory["sum"])
+	assert.Equal(t, visitor.Integer(3), interpreter.GlobalMemory["count"])
+	_, ok := interpreter.GlobalMemory["i"]
+	assert.False(t, ok)
+}
+
+// TestVisitor_Program_LoopErrors tests the runtime errors of the loop statements.
+func TestVisitor_Program_LoopErrors(t *testing.T) {
+	inputs := map[string]string{
+		"BEGIN WHILE 1 DO x := 1 END.":           "1:13: expected the condition of WHILE to be a BOOLEAN, got INTEGER",
+		"BEGIN REPEAT x := 1 UNTIL x END.":       "1:27: expected the condition of UNTIL to be a BOOLEAN, got INTEGER",
+		"BEGIN FOR i := 1 TO 2.5 DO x := 1 END.": "1:21: expected the bounds of FOR to be INTEGER, got REAL",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Program_Case tests the selection of the branches of a CASE statement.
+func TestVisitor_Program_Case(t *testing.T) {
+	input := `PROGRAM Days;
+VAR
+    day, kind : INTEGER;
+    weekend : BOOLEAN;
+BEGIN
+    FOR day := 1 TO 7 DO
+        CASE day OF
+            1..5: kind := kind + 1;
+            6, 7: kind := kind + 10
+        END;
+    weekend := FALSE;
+    CASE weekend OF
+        TRUE: day := 1
+    ELSE
+        day := 2
+    END
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	interpreter.GlobalMemory["kind"] = visitor.Integer(0)
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(25), interpreter.GlobalMemory["kind"])
+	assert.Equal(t, visitor.Integer(2), interpreter.GlobalMemory["day"])
+}
+
+// TestVisitor_Program_CaseErrors tests the runtime errors of the CASE statement.
+func TestVisitor_Program_CaseErrors(t *testing.T) {
+	inputs := map[string]string{
+		"BEGIN CASE 4 OF 1..3: x := 1 END END.": "1:12: none of the CASE labels match the value 4",
+		"BEGIN CASE 1.5 OF 1: END END.":         "1:12: expected the expression of CASE to be of an ordinal type, got REAL",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Expression_Strings tests the concatenation and comparison of characters and
+// strings.
+func TestVisitor_Expression_Strings(t *testing.T) {
+	inputs := make(map[string]visitor.Value)
+	inputs["'a'"] = visitor.Char('a')
+	inputs["''"] = visitor.String("")
+	inputs["'it''s'"] = visitor.String("it's")
+	inputs["#65"] = visitor.Char('A')
+	inputs["'a' + #10"] = visitor.String("a\n")
+	inputs["'ab' + 'c' + ''"] = visitor.String("abc")
+	inputs["'abc' < 'abd'"] = visitor.Boolean(true)
+	inputs["'ab' < 'a'"] = visitor.Boolean(false)
+	inputs["'a' = 'a'"] = visitor.Boolean(true)
+	inputs["'b' >= 'abc'"] = visitor.Boolean(true)
+	inputs["'a' <> 'a' + ''"] = visitor.Boolean(false)
+
+	for input, result := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+
+		expression, err := parser.Expr()
+		assert.Nil(t, err, input)
+
+		visitor := visitor.Visitor{}
+		visit, err := visitor.Visit(expression)
+		assert.Nil(t, err, input)
+
+		assert.Equal(t, result, visit, input)
+	}
+}
+
+// TestVisitor_Program_Strings tests assigning to CHAR and STRING variables and a CASE
+// statement over characters.
+func TestVisitor_Program_Strings(t *testing.T) {
+	input := `PROGRAM Strings;
+VAR
+    c : CHAR;
+    s : STRING;
+    vowels : INTEGER;
+BEGIN
+    c := 'e';
+    s := c;
+    s := s + 'cho' + #33;
+    vowels := 0;
+    CASE c OF
+        'a', 'e', 'i', 'o', 'u': vowels := vowels + 1;
+        'b'..'d': vowels := 0
+    END
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Char('e'), interpreter.GlobalMemory["c"])
+	assert.Equal(t, visitor.String("echo!"), interpreter.GlobalMemory["s"])
+	assert.Equal(t, visitor.Integer(1), interpreter.GlobalMemory["vowels"])
+}
+
+// TestVisitor_Program_StringErrors tests the runtime errors of characters and strings.
+func TestVisitor_Program_StringErrors(t *testing.T) {
+	inputs := map[string]string{
+		"BEGIN x := 'a' + 1 END.":              "1:12: operator + expects CHAR or STRING operands, got CHAR and INTEGER",
+		"BEGIN x := 'a' < 1 END.":              "1:12: unable to compare CHAR with INTEGER",
+		"VAR c : CHAR; BEGIN c := 'ab' END.":   "1:21: unable to assign a value of type STRING to CHAR variable c",
+		"VAR x : INTEGER; BEGIN x := 'a' END.": "1:24: unable to assign a value of type CHAR to INTEGER variable x",
+		"BEGIN CASE 'ab' OF 'a': END END.":     "1:12: expected the expression of CASE to be of an ordinal type, got STRING",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Program_InputOutput tests reading the input with Read and ReadLn and writing
+// the output with Write and WriteLn.
+func TestVisitor_Program_InputOutput(t *testing.T) {
+	input := `PROGRAM Average;
+VAR
+    count, i, number, sum : INTEGER;
+    name : STRING;
+BEGIN
+    ReadLn(name);
+    Read(count);
+    sum := 0;
+    FOR i := 1 TO count DO
+    BEGIN
+        Read(number);
+        sum := sum + number
+    END;
+    WriteLn('Hello ', name, '!');
+    Write('sum:', sum:4);
+    WriteLn(', average:', sum / count:6:2);
+    WriteLn(sum / count:10, count > 2:6)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	var output bytes.Buffer
+	interpreter := visitor.New()
+	interpreter.Input = strings.NewReader("Pascal\n3\n10 20\n  25\n")
+	interpreter.Output = &output
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	expected := "Hello Pascal!\nsum:  55, average: 18.33\n 1.83E+001  TRUE\n"
+	assert.Equal(t, expected, output.String())
+}
+
+// TestVisitor_Program_InputOutputErrors tests the runtime errors of the standard procedures.
+func TestVisitor_Program_InputOutputErrors(t *testing.T) {
+	inputs := map[string]string{
+		"VAR s : STRING; i : INTEGER; BEGIN Read(s, i) END.":     "1:44: unexpected end of input while reading i",
+		"VAR i : INTEGER; BEGIN Read(i) END.":                    "1:29: unable to read i, expected an INTEGER, got \"abc\"",
+		"VAR r : REAL; BEGIN Read(r) END.":                       "1:26: unable to read r, expected a REAL, got \"abc\"",
+		"VAR b : BOOLEAN; BEGIN Read(b) END.":                    "1:29: unable to read BOOLEAN variable b",
+		"BEGIN Read(x) END.":                                     "1:12: unable to read x, its type isn't declared",
+		"BEGIN ReadLn(1) END.":                                   "1:14: expected a variable as argument of readln",
+		"BEGIN WriteLn(1:-1) END.":                               "1:17: expected the field width to be at least 0, got -1",
+		"BEGIN WriteLn(1:2:3) END.":                              "1:19: only REAL values can be written with a number of decimals, got INTEGER",
+		"PROCEDURE p(a : INTEGER); BEGIN END; BEGIN p(1:2) END.": "1:46: only the arguments of Write and WriteLn can have a field width",
+	}
+
+	for source, message := range inputs {
+		lexer, err := scanner.New(source)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, source)
+
+		interpreter := visitor.New()
+		interpreter.Input = strings.NewReader("abc")
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, source)
+	}
+}
+
+// TestVisitor_Expression_Builtins tests the standard functions.
+func TestVisitor_Expression_Builtins(t *testing.T) {
+	inputs := make(map[string]visitor.Value)
+	inputs["abs(-7)"] = visitor.Integer(7)
+	inputs["ABS(-2.5)"] = visitor.Real(2.5)
+	inputs["sqr(-3)"] = visitor.Integer(9)
+	inputs["sqr(0.5)"] = visitor.Real(0.25)
+	inputs["sqrt(2.25)"] = visitor.Real(1.5)
+	inputs["sin(0)"] = visitor.Real(0)
+	inputs["cos(0)"] = visitor.Real(1)
+	inputs["arctan(0)"] = visitor.Real(0)
+	inputs["exp(0)"] = visitor.Real(1)
+	inputs["ln(1)"] = visitor.Real(0)
+	inputs["trunc(-3.7)"] = visitor.Integer(-3)
+	inputs["round(-3.5)"] = visitor.Integer(-4)
+	inputs["round(3.49)"] = visitor.Integer(3)
+	inputs["ord('a')"] = visitor.Integer(97)
+	inputs["ord(TRUE)"] = visitor.Integer(1)
+	inputs["chr(ord('a') + 1)"] = visitor.Char('b')
+	inputs["succ('a')"] = visitor.Char('b')
+	inputs["pred(TRUE)"] = visitor.Boolean(false)
+	inputs["succ(9) * 2"] = visitor.Integer(20)
+	inputs["odd(-3)"] = visitor.Boolean(true)
+	inputs["odd(sqr(2))"] = visitor.Boolean(false)
+
+	for input, result := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+
+		expression, err := parser.Expr()
+		assert.Nil(t, err, input)
+
+		visitor := visitor.Visitor{}
+		visit, err := visitor.Visit(expression)
+		assert.Nil(t, err, input)
+
+		assert.Equal(t, result, visit, input)
+	}
+}
+
+// TestVisitor_Program_Builtins tests the standard procedures Inc and Dec and a function that
+// hides the standard function with the same name.
+func TestVisitor_Program_Builtins(t *testing.T) {
+	input := `PROGRAM Builtins;
+VAR
+    i, j : INTEGER;
+    c : CHAR;
+
+FUNCTION Sqr(n : INTEGER) : INTEGER;
+BEGIN
+    Sqr := n * n * n
+END;
+
+BEGIN
+    i := 10;
+    Inc(i);
+    Dec(i, 4);
+    c := 'a';
+    Inc(c, 3);
+    j := Sqr(2) + Abs(-1)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(7), interpreter.GlobalMemory["i"])
+	assert.Equal(t, visitor.Char('d'), interpreter.GlobalMemory["c"])
+	assert.Equal(t, visitor.Integer(9), interpreter.GlobalMemory["j"])
+}
+
+// TestVisitor_Program_BuiltinErrors tests the runtime errors of the standard procedures and
+// functions.
+func TestVisitor_Program_BuiltinErrors(t *testing.T) {
+	inputs := map[string]string{
+		"BEGIN x := sqrt(-1) END.":                                     "1:12: square root of a negative number",
+		"BEGIN x := ln(0) END.":                                        "1:12: logarithm of a number that isn't positive",
+		"BEGIN x := exp(1000) END.":                                    "1:12: floating point overflow",
+		"BEGIN x := round(1e19) END.":                                  "1:12: integer overflow",
+		"BEGIN x := chr(256) END.":                                     "1:12: ordinal value out of range",
+		"BEGIN x := succ(TRUE) END.":                                   "1:12: ordinal value out of range",
+		"BEGIN x := pred(-9223372036854775807 - 1) END.":               "1:12: integer overflow",
+		"BEGIN x := abs(TRUE) END.":                                    "1:16: abs expects an INTEGER or REAL argument, got BOOLEAN",
+		"BEGIN x := ord(1.5) END.":                                     "1:16: ord expects an argument of an ordinal type, got REAL",
+		"BEGIN x := odd(1, 2) END.":                                    "1:12: odd expects 1 argument, got 2",
+		"BEGIN x := abs END.":                                          "1:12: abs expects 1 argument, got 0",
+		"BEGIN abs(1) END.":                                            "1:7: the result of function abs has to be used",
+		"VAR r : REAL; BEGIN r := 1; inc(r) END.":                      "1:33: inc expects a variable of an ordinal type, got REAL",
+		"VAR i : INTEGER; BEGIN i := 1; inc(i, TRUE) END.":             "1:39: inc expects an INTEGER as second argument, got BOOLEAN",
+		"BEGIN inc(1) END.":                                            "1:11: expected a variable as argument of inc",
+		"VAR i : INTEGER; BEGIN i := 9223372036854775807; inc(i) END.": "1:50: integer overflow",
+	}
+
+	for source, message := range inputs {
+		lexer, err := scanner.New(source)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, source)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, source)
+	}
+}
+
+// TestVisitor_Program_Subroutines tests recursive functions and nested procedures that access
+// the variables of the enclosing scopes.
+func TestVisitor_Program_Subroutines(t *testing.T) {
+	input := `PROGRAM Subroutines;
+VAR
+    x, fact, fib, counter : INTEGER;
+    half : REAL;
+
+FUNCTION Factorial(n : INTEGER) : INTEGER;
+BEGIN
+    IF n = 0 THEN Factorial := 1 ELSE Factorial := n * Factorial(n - 1)
+END;
+
+FUNCTION Fibonacci(n : INTEGER) : INTEGER;
+VAR a, b, i, next : INTEGER;
+BEGIN
+    a := 0;
+    b := 1;
+    FOR i := 1 TO n DO
+    BEGIN
+        next := a + b;
+        a := b;
+        b := next
+    END;
+    Fibonacci := a
+END;
+
+FUNCTION Halve(value : REAL) : REAL;
+BEGIN
+    Halve := value / 2
+END;
+
+PROCEDURE Outer(x : INTEGER);
+VAR local : INTEGER;
+
+    PROCEDURE Inner;
+    BEGIN
+        local := local + x;
+        counter := counter + 1
+    END;
+
+BEGIN
+    local := 100;
+    Inner;
+    Inner;
+    counter := counter + local
+END;
+
+BEGIN
+    x := 5;
+    counter := 0;
+    fact := Factorial(x);
+    fib := Fibonacci(2 * x);
+    half := Halve(x);
+    Outer(x + 1)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(5), interpreter.GlobalMemory["x"])
+	assert.Equal(t, visitor.Integer(120), interpreter.GlobalMemory["fact"])
+	assert.Equal(t, visitor.Integer(55), interpreter.GlobalMemory["fib"])
+	assert.Equal(t, visitor.Real(2.5), interpreter.GlobalMemory["half"])
+	assert.Equal(t, visitor.Integer(114), interpreter.GlobalMemory["counter"])
+
+	_, ok := interpreter.GlobalMemory["local"]
+	assert.False(t, ok)
+	assert.Len(t, interpreter.Stack.Records, 1)
+}
+
+// TestVisitor_Program_SubroutineErrors tests the runtime errors of procedure and function calls.
+func TestVisitor_Program_SubroutineErrors(t *testing.T) {
+	inputs := map[string]string{
+		"BEGIN p END.":         "1:7: procedure p is not declared",
+		"BEGIN x := f(1) END.": "1:12: function f is not declared",
+		"PROCEDURE p(a : INTEGER); BEGIN END; BEGIN p END.":         "1:44: p expects 1 argument, got 0",
+		"PROCEDURE p(a : INTEGER); BEGIN END; BEGIN p(TRUE) END.":   "1:46: unable to pass a value of type BOOLEAN as INTEGER parameter a",
+		"PROCEDURE p; BEGIN END; BEGIN x := p END.":                 "1:36: procedure p doesn't return a value",
+		"FUNCTION f : INTEGER; BEGIN END; BEGIN f END.":             "1:40: the result of function f has to be used",
+		"FUNCTION f : INTEGER; BEGIN END; BEGIN x := f END.":        "1:45: function f didn't assign a result",
+		"FUNCTION f : INTEGER; BEGIN f := f END; BEGIN x := f END.": "1:34: stack overflow in call to f",
+		"PROCEDURE p; BEGIN END; BEGIN p := 1 END.":                 "1:31: unable to assign to p, it isn't a variable",
+		"VAR p : INTEGER; PROCEDURE p; BEGIN END; BEGIN END.":       "1:18: procedure p is declared more than once",
+		"PROCEDURE p; BEGIN x := 1 END; BEGIN p; y := x END.":       "1:46: variable x is used before it is assigned a value",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Program_ParameterModes tests whether VAR parameters refer to the storage of the
+// caller and CONST parameters are read-only.
+func TestVisitor_Program_ParameterModes(t *testing.T) {
+	input := `PROGRAM Modes;
+VAR
+    x, y, total : INTEGER;
+
+PROCEDURE Swap(VAR a, b : INTEGER);
+VAR temporary : INTEGER;
+BEGIN
+    temporary := a;
+    a := b;
+    b := temporary
+END;
+
+PROCEDURE Accumulate(VAR sum : INTEGER; CONST value : INTEGER);
+    PROCEDURE Add(VAR target : INTEGER);
+    BEGIN
+        target := target + value
+    END;
+BEGIN
+    Add(sum)
+END;
+
+FUNCTION Increment(VAR counter : INTEGER) : INTEGER;
+BEGIN
+    counter := counter + 1;
+    Increment := counter
+END;
+
+BEGIN
+    x := 1;
+    y := 2;
+    Swap(x, y);
+    total := 10;
+    Accumulate(total, x + y);
+    total := total + Increment(y)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	interpreter := visitor.New()
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, visitor.Integer(2), interpreter.GlobalMemory["x"])
+	assert.Equal(t, visitor.Integer(2), interpreter.GlobalMemory["y"])
+	assert.Equal(t, visitor.Integer(15), interpreter.GlobalMemory["total"])
+}
+
+// TestVisitor_Program_ParameterModeErrors tests the errors of passing arguments to VAR
+// parameters and of assigning CONST parameters.
+func TestVisitor_Program_ParameterModeErrors(t *testing.T) {
+	inputs := map[string]string{
+		"PROCEDURE p(VAR a : INTEGER); BEGIN END; BEGIN p(1 + 2) END.":                                             "1:50: expected a variable as argument for VAR parameter a",
+		"PROCEDURE p(VAR a : INTEGER); BEGIN END; BEGIN p(p) END.":                                                 "1:50: expected a variable as argument for VAR parameter a",
+		"VAR r : REAL; PROCEDURE p(VAR a : INTEGER); BEGIN END; BEGIN p(r) END.":                                   "1:64: unable to pass REAL variable r as INTEGER VAR parameter a",
+		"PROCEDURE p(CONST a : INTEGER); BEGIN a := 1 END; BEGIN p(1) END.":                                        "1:39: unable to assign to CONST parameter a",
+		"PROCEDURE q(VAR b : INTEGER); BEGIN END; PROCEDURE p(CONST a : INTEGER); BEGIN q(a) END; BEGIN p(1) END.": "1:82: unable to pass a as argument for VAR parameter b",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Program_Arrays tests indexing arrays with integers, characters and booleans,
+// multidimensional arrays and whether assigning an array copies its elements.
+func TestVisitor_Program_Arrays(t *testing.T) {
+	input := `PROGRAM Arrays;
+VAR
+    a, b : ARRAY[1..3] OF INTEGER;
+    grid : ARRAY[1..2, 'a'..'b'] OF REAL;
+    flags : ARRAY[FALSE..TRUE] OF CHAR;
+    i, sum : INTEGER;
+
+PROCEDURE Fill(VAR target : ARRAY[1..3] OF INTEGER; value : INTEGER);
+VAR i : INTEGER;
+BEGIN
+    FOR i := 1 TO 3 DO
+        target[i] := value * i
+END;
+
+BEGIN
+    Fill(a, 10);
+    b := a;
+    b[1] := 0;
+    Read(a[2]);
+    Inc(a[3], 5);
+    grid[1, 'a'] := 1;
+    grid[1]['b'] := grid[1, 'a'] / 2;
+    grid[2] := grid[1];
+    flags[1 > 2] := 'n';
+    flags[TRUE] := 'y';
+    sum := 0;
+    FOR i := 1 TO 3 DO
+        sum := sum + a[i] + b[i];
+    WriteLn(a[1], ' ', a[2], ' ', a[3], ' ', b[1], ' ', sum);
+    WriteLn(grid[2, 'b']:4:2, flags[FALSE], flags[TRUE])
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	var output bytes.Buffer
+	interpreter := visitor.New()
+	interpreter.Input = strings.NewReader("7")
+	interpreter.Output = &output
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "10 7 35 0 102\n0.50ny\n", output.String())
+}
+
+// TestVisitor_Program_ArrayReferences tests passing elements of arrays as arguments for VAR
+// parameters, the index of an element is evaluated once when the procedure is called.
+func TestVisitor_Program_ArrayReferences(t *testing.T) {
+	input := `PROGRAM References;
+VAR
+    a : ARRAY[1..3] OF INTEGER;
+    i : INTEGER;
+
+PROCEDURE Swap(VAR x, y : INTEGER);
+VAR
+    t : INTEGER;
+BEGIN
+    t := x;
+    x := y;
+    y := t
+END;
+
+PROCEDURE Next(VAR x : INTEGER);
+BEGIN
+    i := i + 1;
+    x := x * 10
+END;
+
+BEGIN
+    a[1] := 1; a[2] := 2; a[3] := 3;
+    Swap(a[1], a[3]);
+    i := 1;
+    Next(a[i]);
+    WriteLn(a[1], ' ', a[2], ' ', a[3], ' ', i)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	var output bytes.Buffer
+	interpreter := visitor.New()
+	interpreter.Output = &output
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "30 2 1 2\n", output.String())
+}
+
+// TestVisitor_Program_ArrayErrors tests the runtime errors of indexing arrays.
+func TestVisitor_Program_ArrayErrors(t *testing.T) {
+	inputs := map[string]string{
+		"VAR a : ARRAY[1..3] OF INTEGER; BEGIN a[4] := 1 END.":                                        "1:41: index 4 of a is out of range 1..3",
+		"VAR a : ARRAY['a'..'c'] OF INTEGER; BEGIN a['d'] := 1 END.":                                  "1:45: index 'd' of a is out of range 'a'..'c'",
+		"VAR a : ARRAY[1..3] OF INTEGER; BEGIN a['a'] := 1 END.":                                      "1:41: expected an index of type INTEGER, got CHAR",
+		"VAR a : ARRAY[1..3] OF INTEGER; BEGIN a[1.5] := 1 END.":                                      "1:41: expected an index of type INTEGER, got REAL",
+		"VAR a : ARRAY[1..3] OF INTEGER; i : INTEGER; BEGIN i := a[2] END.":                           "1:57: element a[2] is used before it is assigned a value",
+		"VAR a : ARRAY[1..3] OF INTEGER; BEGIN a[1, 1] := 1 END.":                                     "1:44: too many indexes for variable a",
+		"VAR i : INTEGER; BEGIN i := 1; i[1] := 1 END.":                                               "1:34: unable to index INTEGER variable i",
+		"VAR a : ARRAY[5..4] OF INTEGER; BEGIN END.":                                                  "1:15: index range 5..4 is empty",
+		"VAR a : ARRAY[1..'c'] OF INTEGER; BEGIN END.":                                                "1:15: expected the bounds of an index range to be of the same type, got INTEGER and CHAR",
+		"VAR a : ARRAY[1..100000, 1..1000] OF INTEGER; BEGIN END.":                                    "1:26: ARRAY type is too large, it can have at most 16777216 elements",
+		"VAR a : ARRAY[1..3] OF INTEGER; PROCEDURE p(VAR x : INTEGER); BEGIN END; BEGIN p(a[4]) END.": "1:84: index 4 of a is out of range 1..3",
+		"VAR a : ARRAY[1..3] OF INTEGER; b : ARRAY[1..4] OF INTEGER; BEGIN a := b END.":               "1:67: unable to assign an ARRAY value of another type to variable a",
+		"VAR a : ARRAY[1..3] OF INTEGER; BEGIN a[1] := 'x' END.":                                      "1:39: unable to assign a value of type CHAR to INTEGER element a[1]",
+		"VAR a : ARRAY[1..2, 1..2] OF INTEGER; b : ARRAY[1..3] OF INTEGER; BEGIN a[1] := b END.":      "1:73: unable to assign an ARRAY value of another type to element a[1]",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Program_Records tests the RECORD variables, the assignment of records and the WITH
+// statement.
+func TestVisitor_Program_Records(t *testing.T) {
+	input := `PROGRAM Records;
+TYPE
+    Point = RECORD
+        x, y : INTEGER
+    END;
+    Segment = RECORD
+        from, towards : Point;
+        name : STRING
+    END;
+    Path = ARRAY[1..2] OF Point;
+
+VAR
+    p, q : Point;
+    line : Segment;
+    points : Path;
+
+PROCEDURE Move(VAR target : Point; dx : INTEGER);
+BEGIN
+    Inc(target.x, dx)
+END;
+
+PROCEDURE Shift(VAR i : INTEGER; di : INTEGER);
+BEGIN
+    i := i + di
+END;
+
+BEGIN
+    p.x := 1;
+    p.y := 2;
+    q := p;
+    q.x := 10;
+    Shift(q.y, 1);
+    Move(p, 5);
+    Read(p.y);
+    line.from := p;
+    WITH line, towards DO
+    BEGIN
+        name := 'diagonal';
+        x := from.x + 1;
+        y := q.x;
+        Shift(y, 100)
+    END;
+    points[2] := line.towards;
+    WITH points[2] DO
+        points[1].x := x * 2;
+    WriteLn(p.x, ' ', p.y, ' ', q.x, ' ', q.y);
+    WriteLn(line.name, ' ', line.from.y, ' ', line.towards.x, ' ', points[1].x, ' ', points[2].y)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	var output bytes.Buffer
+	interpreter := visitor.New()
+	interpreter.Input = strings.NewReader("7")
+	interpreter.Output = &output
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "6 7 10 3\ndiagonal 7 7 14 110\n", output.String())
+}
+
+// TestVisitor_Program_RecordErrors tests the runtime errors of TYPE declarations, fields and
+// WITH statements.
+func TestVisitor_Program_RecordErrors(t *testing.T) {
+	inputs := map[string]string{
+		"VAR p : point; BEGIN END.": "1:9: type point is not declared",
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; i : INTEGER; BEGIN i := p.x END.":                                      "1:69: field p.x is used before it is assigned a value",
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; i : INTEGER; BEGIN WITH p DO i := x END.":                              "1:79: field p.x is used before it is assigned a value",
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; BEGIN p.y := 1 END.":                                                   "1:53: RECORD variable p has no field y",
+		"TYPE r = RECORD x : INTEGER END; VAR p : ARRAY[1..2] OF r; BEGIN p[1].y := 1 END.":                                 "1:71: RECORD element p[1] has no field y",
+		"VAR i : INTEGER; BEGIN i := 1; i.x := 1 END.":                                                                      "1:34: INTEGER variable i has no field x",
+		"VAR i : INTEGER; BEGIN i := 1; WITH i DO END.":                                                                     "1:37: expected a RECORD variable in WITH, got INTEGER",
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; BEGIN p.x := 'a' END.":                                                 "1:51: unable to assign a value of type CHAR to INTEGER field p.x",
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; BEGIN WITH p DO FOR x := 1 TO 2 DO END.":                               "1:65: unable to use field x as control variable",
+		"TYPE r = RECORD x : INTEGER END; VAR p : r; PROCEDURE q(VAR i : INTEGER); BEGIN i := i + 1 END; BEGIN q(p.x) END.": "1:86: field p.x is used before it is assigned a value",
+		"TYPE r = RECORD b : BOOLEAN END; VAR p : r; PROCEDURE q(VAR i : INTEGER); BEGIN END; BEGIN WITH p DO q(b) END.":    "1:104: unable to pass BOOLEAN field p.b as INTEGER VAR parameter i",
+		"TYPE r = RECORD x : INTEGER END; s = RECORD y : INTEGER END; VAR p : r; q : s; BEGIN p := q END.":                  "1:86: unable to assign a RECORD value of another type to variable p",
+		"TYPE r = RECORD a : ARRAY[1..2] OF INTEGER END; VAR p : ARRAY[1..2] OF r; i : INTEGER; BEGIN i := p[1].a[2] END.":  "1:99: element p[1].a[2] is used before it is assigned a value",
+		"TYPE r = RECORD a : ARRAY[1..2] OF INTEGER END; VAR p : ARRAY[1..2] OF r; BEGIN p[1].a[3] := 1 END.":               "1:88: index 3 of p[1].a is out of range 1..2",
+		"TYPE r = RECORD a : ARRAY[1..100000] OF INTEGER END; VAR p : ARRAY[1..1000] OF r; BEGIN END.":                      "1:62: ARRAY type is too large, it can have at most 16777216 elements",
+	}
+
+	for input, message := range inputs {
+		lexer, err := scanner.New(input)
+		assert.Nil(t, err)
+
+		parser := parser2.New(lexer)
+		program, err := parser.Program()
+		assert.Nil(t, err, input)
+
+		interpreter := visitor.New()
+		err = interpreter.Interpret(program)
+		assert.EqualError(t, err, message, input)
+	}
+}
+
+// TestVisitor_Program_StandardTypeNames tests that the names of the standard types can be used
+// as identifiers and that a declared type hides the standard type with the same name.
+func TestVisitor_Program_StandardTypeNames(t *testing.T) {
+	input := `PROGRAM Names;
+TYPE
+    Entry = RECORD
+        string : STRING;
+        integer : INTEGER
+    END;
+    Real = INTEGER;
+
+VAR
+    char : CHAR;
+    e : Entry;
+    r : Real;
+
+BEGIN
+    char := 'c';
+    e.string := 'entry';
+    e.integer := 7;
+    r := e.integer DIV 2;
+    WriteLn(char, ' ', e.string, ' ', e.integer, ' ', r)
+END.`
+
+	lexer, err := scanner.New(input)
+	assert.Nil(t, err)
+
+	parser := parser2.New(lexer)
+	program, err := parser.Program()
+	assert.Nil(t, err)
+
+	_, messages := analyze(t, input)
+	assert.Nil(t, messages)
+
+	var output bytes.Buffer
+	interpreter := visitor.New()
+	interpreter.Output = &output
+	err = interpreter.Interpret(program)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "c entry 7 3\n", output.String())
+}